@@ -3,6 +3,7 @@ package stream
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -303,3 +304,25 @@ func BenchmarkParser_RealWorldLLMStream(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkParser_TemplatePool 测试模板 + sync.Pool 复用 Parser 的性能
+func BenchmarkParser_TemplatePool(b *testing.B) {
+	json := `{"status": "running", "progress": 42, "message": "processing"}`
+	tmpl, err := NewParserTemplate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	tmpl.On("$.status", func(ev Event) {})
+	tmpl.On("$.progress", func(ev Event) {})
+	tmpl.On("$.message", func(ev Event) {})
+	pool := sync.Pool{New: func() any { return tmpl.NewParser() }}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := pool.Get().(*Parser)
+		if err := p.FeedString(json); err != nil {
+			b.Fatal(err)
+		}
+		p.Reset()
+		pool.Put(p)
+	}
+}
@@ -0,0 +1,209 @@
+package stream
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Backpressure 表示事件通道缓冲区已满时的处理策略
+type Backpressure int
+
+const (
+	// BackpressureBlock 阻塞解析，直到消费者取走事件或 ctx 结束（默认）
+	BackpressureBlock Backpressure = iota
+	// BackpressureDropOldest 丢弃缓冲区中最早的事件
+	BackpressureDropOldest
+	// BackpressureCoalesce 缓冲区已满时将同一路径上连续的 Append 事件合并为一个，无法合并时阻塞；
+	// 缓冲区有空间时每个增量单独投递
+	BackpressureCoalesce
+)
+
+// defaultChannelBuffer 事件通道的默认缓冲区大小
+const defaultChannelBuffer = 64
+
+// ChannelOptions 事件通道的配置
+type ChannelOptions struct {
+	// BufferSize 缓冲的事件数，0 表示使用默认值 64
+	BufferSize int
+	// Backpressure 缓冲区已满时的处理策略
+	Backpressure Backpressure
+}
+
+// eventSink 将事件转发到通道，内部队列按 Backpressure 策略处理积压
+type eventSink struct {
+	patterns []PathPattern
+	opts     ChannelOptions
+	ctx      context.Context
+	out      chan Event
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []Event
+	ended  bool // 已收到最后一个事件，队列排空后关闭通道
+	closed bool // 通道已关闭或 ctx 已结束，不再接收事件
+}
+
+// Events 返回匹配 patterns 的事件通道（不传 pattern 时接收所有事件），使用默认的缓冲区和阻塞策略
+// 容器事件按容器自身的路径匹配（同 WithContainerPath）
+// 通道在 EventStreamEnd 或致命错误（最后一个事件为携带 Err 的 EventError）之后关闭，
+// ctx 结束时也会关闭；阻塞策略下消费者必须持续读取，否则 Feed 会被阻塞
+func (p *Parser) Events(ctx context.Context, patterns ...string) <-chan Event {
+	return p.EventsWith(ctx, ChannelOptions{}, patterns...)
+}
+
+// EventsWith 与 Events 相同，但可以指定缓冲区大小和 Backpressure 策略
+func (p *Parser) EventsWith(ctx context.Context, opts ChannelOptions, patterns ...string) <-chan Event {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultChannelBuffer
+	}
+	s := &eventSink{
+		opts:  opts,
+		ctx:   ctx,
+		out:   make(chan Event),
+		queue: make([]Event, 0, opts.BufferSize),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	for _, expr := range patterns {
+		pat, err := compilePatternSyntax(expr, p.opts.PathSyntax)
+		if err != nil {
+			panic(err)
+		}
+		s.patterns = append(s.patterns, pat)
+	}
+
+	p.sinks = append(p.sinks, s)
+	go s.pump()
+	context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+	return s.out
+}
+
+// dispatchSinks 将事件交给所有事件通道，并移除已关闭的通道
+func (p *Parser) dispatchSinks(ev Event, segments []PathSegment) {
+	p.sinks = slices.DeleteFunc(p.sinks, func(s *eventSink) bool {
+		switch {
+		case ev.Type == EventStreamEnd:
+			s.push(ev, true)
+		case s.matches(segments):
+			s.push(ev, false)
+		}
+		return s.isClosed()
+	})
+}
+
+// failSinks 在致命错误时向所有事件通道发出 EventError 并关闭它们
+func (p *Parser) failSinks(err error) {
+	for _, s := range p.sinks {
+		s.push(Event{Type: EventError, Err: err, Document: p.docIndex, syntax: p.opts.PathSyntax}, true)
+	}
+	p.sinks = nil
+}
+
+// endSinks 在发出已缓冲的事件后关闭所有事件通道
+func (p *Parser) endSinks() {
+	for _, s := range p.sinks {
+		s.mu.Lock()
+		s.ended = true
+		s.mu.Unlock()
+		s.cond.Broadcast()
+	}
+	p.sinks = nil
+}
+
+func (s *eventSink) matches(segments []PathSegment) bool {
+	if len(s.patterns) == 0 {
+		return true
+	}
+	for _, pat := range s.patterns {
+		if match(pat.Segments, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *eventSink) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed || s.ended
+}
+
+// push 按 Backpressure 策略将事件放入队列，last 表示这是通道的最后一个事件
+func (s *eventSink) push(ev Event, last bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.ended {
+		return
+	}
+
+	for len(s.queue) >= s.opts.BufferSize && !s.closed {
+		if s.opts.Backpressure == BackpressureCoalesce && s.coalesce(ev) {
+			return
+		}
+		if s.opts.Backpressure == BackpressureDropOldest {
+			s.queue = s.queue[1:]
+			break
+		}
+		s.cond.Wait()
+	}
+	if s.closed {
+		return
+	}
+	s.queue = append(s.queue, ev)
+	s.ended = last
+	s.cond.Broadcast()
+}
+
+// coalesce 尝试将 Append 事件合并到队尾的同路径 Append 事件上，只在缓冲区已满时调用
+func (s *eventSink) coalesce(ev Event) bool {
+	if len(s.queue) == 0 || ev.Value == nil || !ev.Value.Append {
+		return false
+	}
+	tail := &s.queue[len(s.queue)-1]
+	if tail.Type != ev.Type || tail.Document != ev.Document || tail.Value == nil || !tail.Value.Append ||
+		tail.Value.Kind != ev.Value.Kind || !slices.Equal(tail.pathSegments, ev.pathSegments) {
+		return false
+	}
+	prev, ok1 := tail.Value.Value.(string)
+	next, ok2 := ev.Value.Value.(string)
+	if !ok1 || !ok2 {
+		return false
+	}
+	v := *ev.Value
+	v.Value = prev + next
+	tail.Value = &v
+	tail.pathCache = ""
+	return true
+}
+
+// pump 将队列中的事件依次发送到通道，直到最后一个事件发出或 ctx 结束
+func (s *eventSink) pump() {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed && !s.ended {
+			s.cond.Wait()
+		}
+		if s.closed || len(s.queue) == 0 {
+			s.closed = true
+			s.mu.Unlock()
+			s.cond.Broadcast()
+			return
+		}
+		ev := s.queue[0]
+		s.queue = s.queue[1:]
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		select {
+		case s.out <- ev:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
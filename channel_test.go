@@ -0,0 +1,170 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func drain(ch <-chan Event) []Event {
+	var events []Event
+	for ev := range ch {
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestParser_Events(t *testing.T) {
+	p := NewParser()
+	ch := p.Events(context.Background(), "$.items[*].id")
+	done := make(chan []Event)
+	go func() { done <- drain(ch) }()
+
+	if err := p.FeedString(`{"items": [{"id": 1}, {"id": 2}]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	events := <-done
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	for i, want := range []string{"$.items[0].id=1", "$.items[1].id=2"} {
+		if got := events[i].Path() + "=" + events[i].Value.String(); got != want {
+			t.Errorf("event %d: expected %s, got %s", i, want, got)
+		}
+	}
+	if events[2].Type != EventStreamEnd {
+		t.Errorf("expected channel to end with StreamEnd, got %s", events[2].Type)
+	}
+}
+
+func TestParser_EventsFatalError(t *testing.T) {
+	p := NewParser()
+	ch := p.Events(context.Background())
+	done := make(chan []Event)
+	go func() { done <- drain(ch) }()
+
+	if err := p.FeedString(`{"a": 1}}`); err == nil {
+		t.Fatal("expected error")
+	}
+
+	events := <-done
+	last := events[len(events)-1]
+	if last.Type != EventError || !errors.Is(last.Err, ErrMismatchedBrace) {
+		t.Errorf("expected final EventError, got %s %v", last.Type, last.Err)
+	}
+}
+
+func TestParser_EventsDropOldest(t *testing.T) {
+	p := NewParser()
+	ch := p.EventsWith(context.Background(), ChannelOptions{
+		BufferSize:   2,
+		Backpressure: BackpressureDropOldest,
+	}, "$[*]")
+
+	// 没有消费者时 Feed 也不会阻塞
+	if err := p.FeedString(`[1, 2, 3, 4, 5, 6, 7, 8]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	p.Close()
+
+	events := drain(ch)
+	if len(events) > 3 {
+		t.Fatalf("expected at most 3 events, got %d", len(events))
+	}
+	if events[len(events)-1].Type != EventStreamEnd {
+		t.Errorf("expected StreamEnd to be kept, got %s", events[len(events)-1].Type)
+	}
+	if prev := events[len(events)-2]; prev.Path() != "$[7]" {
+		t.Errorf("expected the newest item to be kept, got %s", prev.Path())
+	}
+}
+
+func TestParser_EventsCoalesce(t *testing.T) {
+	p := NewParser()
+	ch := p.EventsWith(context.Background(), ChannelOptions{
+		BufferSize:   1,
+		Backpressure: BackpressureCoalesce,
+	}, "$.answer")
+
+	answer := "hello, streaming world"
+	p.FeedString(`{"answer": "`)
+	for _, r := range answer {
+		if err := p.FeedString(string(r)); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	// 消费者跟上之前，增量已被合并，不会阻塞解析
+	var text strings.Builder
+	appends := 0
+	go func() {
+		p.FeedString(`"}`)
+		p.Close()
+	}()
+	for ev := range ch {
+		if ev.Value != nil && ev.Value.Append {
+			appends++
+			text.WriteString(ev.Value.String())
+		}
+	}
+	if text.String() != answer {
+		t.Errorf("expected coalesced text %q, got %q", answer, text.String())
+	}
+	if appends >= len(answer) {
+		t.Errorf("expected appends to be coalesced, got %d events", appends)
+	}
+}
+
+// TestParser_EventsCoalesceWithRoom 测试缓冲区有空间时增量不会被合并
+func TestParser_EventsCoalesceWithRoom(t *testing.T) {
+	p := NewParser()
+	ch := p.EventsWith(context.Background(), ChannelOptions{
+		BufferSize:   16,
+		Backpressure: BackpressureCoalesce,
+	}, "$.answer")
+
+	for _, chunk := range []string{`{"answer": "a`, "b", "c", `"}`} {
+		if err := p.FeedString(chunk); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+	p.Close()
+
+	var chunks []string
+	for ev := range ch {
+		if ev.Value != nil && ev.Value.Append {
+			chunks = append(chunks, ev.Value.String())
+		}
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(chunks, want) {
+		t.Errorf("expected separate appends %q, got %q", want, chunks)
+	}
+}
+
+func TestParser_EventsContextCancel(t *testing.T) {
+	p := NewParser()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := p.EventsWith(ctx, ChannelOptions{BufferSize: 1})
+
+	fed := make(chan error)
+	go func() { fed <- p.FeedString(`[1, 2, 3, 4]`) }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-fed:
+		if err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FeedString() still blocked after cancel")
+	}
+	drain(ch)
+}
@@ -0,0 +1,393 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// checkpointVersion 检查点格式版本，格式不兼容时递增
+const checkpointVersion = 3
+
+// checkpointFrame 结构帧的可序列化形式
+type checkpointFrame struct {
+	Kind  frameKind `json:"kind"`
+	ID    uint64    `json:"id,omitempty"`
+	Key   string    `json:"key,omitempty"`
+	Keys  int       `json:"keys,omitempty"`
+	Index int       `json:"index,omitempty"`
+	Val   any       `json:"val,omitempty"`
+	Need  []string  `json:"need,omitempty"`
+}
+
+// checkpointOptions 影响解析状态的选项，恢复时必须与目标 Parser 一致
+type checkpointOptions struct {
+	Materialize    bool `json:"materialize,omitempty"`
+	PartialNumbers bool `json:"partial_numbers,omitempty"`
+	PartialKeys    bool `json:"partial_keys,omitempty"`
+	Recovery       bool `json:"recovery,omitempty"`
+}
+
+// stateOptions 返回 Parser 当前影响解析状态的选项
+func (p *Parser) stateOptions() checkpointOptions {
+	return checkpointOptions{
+		Materialize:    p.opts.Materialize,
+		PartialNumbers: p.opts.PartialNumbers,
+		PartialKeys:    p.opts.PartialKeys,
+		Recovery:       p.opts.Recovery,
+	}
+}
+
+// checkpoint Parser 与 Tokenizer 状态的可序列化形式
+type checkpoint struct {
+	Version       int               `json:"version"`
+	Options       checkpointOptions `json:"options"`
+	State         parserState       `json:"state"`
+	Stack         []checkpointFrame `json:"stack"`
+	CurValueKind  valueKind         `json:"cur_value_kind"`
+	CurString     string            `json:"cur_string,omitempty"`
+	CurNumber     string            `json:"cur_number,omitempty"`
+	NumberFlushed int               `json:"number_flushed,omitempty"`
+	KeyFlushed    int               `json:"key_flushed,omitempty"`
+	ChunkBuffer   string            `json:"chunk_buffer,omitempty"`
+	ChunkRunes    int               `json:"chunk_runes,omitempty"`
+	LastFlush     time.Time         `json:"last_flush"`
+	LastValueKind ValueKind         `json:"last_value_kind"`
+	Bytes         int               `json:"bytes"`
+	Skipping      bool              `json:"skipping,omitempty"`
+	SkipDepth     int               `json:"skip_depth,omitempty"`
+	CurToken      TokenType         `json:"cur_token"`
+	DocIndex      int               `json:"doc_index"`
+	InDocument    bool              `json:"in_document,omitempty"`
+	RootValue     any               `json:"root_value,omitempty"`
+	Containers    uint64            `json:"containers,omitempty"`
+	Unions        []checkpointUnion `json:"unions,omitempty"`
+
+	TokenizerState tokenizerState `json:"tokenizer_state"`
+	TokenizerBuf   string         `json:"tokenizer_buf,omitempty"`
+	TokenizerN     int            `json:"tokenizer_n,omitempty"`
+}
+
+// Checkpoint 将当前解析状态（结构帧栈、parser 与 tokenizer 状态、未完成的字符串/数字/关键字缓冲区）
+// 序列化为字节，之后可通过 Restore 在另一个 Parser（甚至另一个进程）中继续解析
+// 订阅、必需字段声明和选项不会被保存，只记录影响解析状态的选项（Materialize、PartialNumbers、PartialKeys、Recovery）用于恢复时校验；
+// OnUnion 进行中元素的状态（选中的判别值和判别字段到达前缓冲的事件）会被保存，恢复时按 OnUnion 订阅的注册顺序对应；
+// 已出错或已关闭的 Parser 返回 ErrInvalidState，缓冲的事件中有无法序列化的错误（EventError）时同样返回 ErrInvalidState
+func (p *Parser) Checkpoint() ([]byte, error) {
+	if p.err != nil {
+		return nil, fmt.Errorf("%w: parser has failed: %v", ErrInvalidState, p.err)
+	}
+	if p.closed {
+		return nil, fmt.Errorf("%w: parser is closed", ErrInvalidState)
+	}
+
+	cp := checkpoint{
+		Version:        checkpointVersion,
+		Options:        p.stateOptions(),
+		State:          p.state,
+		Stack:          make([]checkpointFrame, len(p.stack)),
+		CurValueKind:   p.curValueKind,
+		CurString:      p.curString.String(),
+		CurNumber:      p.curNumber.String(),
+		NumberFlushed:  p.numberFlushed,
+		KeyFlushed:     p.keyFlushed,
+		ChunkBuffer:    p.chunkBuffer.String(),
+		ChunkRunes:     p.chunkRunes,
+		LastFlush:      p.lastFlush,
+		LastValueKind:  p.lastValueKind,
+		Bytes:          p.bytes,
+		Skipping:       p.skipping,
+		SkipDepth:      p.skipDepth,
+		CurToken:       p.curToken,
+		DocIndex:       p.docIndex,
+		InDocument:     p.inDocument,
+		RootValue:      p.rootValue,
+		Containers:     p.containers,
+		TokenizerState: p.tokenizer.state,
+		TokenizerBuf:   string(p.tokenizer.buf),
+		TokenizerN:     p.tokenizer.n,
+	}
+	for i, f := range p.stack {
+		cp.Stack[i] = checkpointFrame{
+			Kind:  f.kind,
+			ID:    f.id,
+			Key:   f.key,
+			Keys:  f.keys,
+			Index: f.index,
+			Val:   f.val,
+			Need:  f.need,
+		}
+	}
+
+	unions, err := p.checkpointUnions()
+	if err != nil {
+		return nil, err
+	}
+	cp.Unions = unions
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCheckpoint, err)
+	}
+	return data, nil
+}
+
+// Restore 从 Checkpoint 的结果恢复解析状态，保留当前 Parser 的选项和订阅
+// 之后继续 Feed 剩余输入，产生的事件序列与不中断解析时完全一致
+// 恢复的 Parser 应使用与创建检查点时相同的选项，其中 Materialize、PartialNumbers、PartialKeys、Recovery 不一致时返回 ErrInvalidCheckpoint；
+// 检查点中有进行中的 OnUnion 元素时，恢复的 Parser 必须以相同的顺序注册相同的 OnUnion 订阅
+// Restore 会先调用 Reset：尚未关闭的事件通道被关闭，等待中的 Future 以 ErrFieldMissing 失败，作用域订阅被移除
+func (p *Parser) Restore(data []byte) error {
+	var cp checkpoint
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&cp); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCheckpoint, err)
+	}
+	if cp.Version != checkpointVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidCheckpoint, cp.Version)
+	}
+	if cp.Options != p.stateOptions() {
+		return fmt.Errorf("%w: checkpoint options %+v do not match parser options %+v",
+			ErrInvalidCheckpoint, cp.Options, p.stateOptions())
+	}
+	if err := cp.validate(); err != nil {
+		return err
+	}
+	unionSubs := p.unionSubs()
+	for _, cu := range cp.Unions {
+		if cu.Sub < 0 || cu.Sub >= len(unionSubs) ||
+			unionSubs[cu.Sub].expr != cu.Expr || unionSubs[cu.Sub].union.field != cu.Field {
+			return fmt.Errorf("%w: no matching OnUnion(%q, %q) subscription", ErrInvalidCheckpoint, cu.Expr, cu.Field)
+		}
+	}
+
+	p.Reset()
+	for _, f := range cp.Stack {
+		p.stack = append(p.stack, frame{
+			kind:  f.Kind,
+			id:    f.ID,
+			key:   f.Key,
+			keys:  f.Keys,
+			index: f.Index,
+			val:   f.Val,
+			need:  f.Need,
+		})
+	}
+	p.state = cp.State
+	p.curValueKind = cp.CurValueKind
+	p.curString.WriteString(cp.CurString)
+	p.curNumber.WriteString(cp.CurNumber)
+	p.numberFlushed = cp.NumberFlushed
+	p.keyFlushed = cp.KeyFlushed
+	p.chunkBuffer.WriteString(cp.ChunkBuffer)
+	p.chunkRunes = cp.ChunkRunes
+	p.lastFlush = cp.LastFlush
+	p.lastValueKind = cp.LastValueKind
+	p.bytes = cp.Bytes
+	p.skipping = cp.Skipping
+	p.skipDepth = cp.SkipDepth
+	p.curToken = cp.CurToken
+	p.docIndex = cp.DocIndex
+	p.inDocument = cp.InDocument
+	p.rootValue = cp.RootValue
+	p.containers = cp.Containers
+	for _, cu := range cp.Unions {
+		u := unionSubs[cu.Sub].union
+		s := p.unionState(u)
+		s.active = true
+		if cu.Chosen {
+			s.chosen = u.handler(cu.Key)
+			s.key = cu.Key
+		}
+		for _, ce := range cu.Buffer {
+			s.buffer = append(s.buffer, ce.event(p))
+		}
+	}
+
+	p.tokenizer.state = cp.TokenizerState
+	p.tokenizer.buf = append(p.tokenizer.buf[:0], []rune(cp.TokenizerBuf)...)
+	p.tokenizer.n = cp.TokenizerN
+	return nil
+}
+
+// validate 检查检查点中的状态是否合法，避免恢复出无法继续解析的 Parser
+func (cp *checkpoint) validate() error {
+	if cp.State < pIdle || cp.State > pArrAfterValue {
+		return fmt.Errorf("%w: invalid parser state %d", ErrInvalidCheckpoint, cp.State)
+	}
+	if cp.TokenizerState < tIdle || cp.TokenizerState > tKeyword {
+		return fmt.Errorf("%w: invalid tokenizer state %d", ErrInvalidCheckpoint, cp.TokenizerState)
+	}
+	if (cp.State == pIdle) != (len(cp.Stack) == 0) {
+		return fmt.Errorf("%w: stack does not match parser state", ErrInvalidCheckpoint)
+	}
+	for _, f := range cp.Stack {
+		if f.Kind != frameObject && f.Kind != frameArray {
+			return fmt.Errorf("%w: invalid frame kind %d", ErrInvalidCheckpoint, f.Kind)
+		}
+		if f.Val == nil {
+			if cp.Options.Materialize {
+				return fmt.Errorf("%w: frame holds no value while materializing", ErrInvalidCheckpoint)
+			}
+			continue
+		}
+		if _, ok := f.Val.(map[string]any); f.Kind == frameObject && !ok {
+			return fmt.Errorf("%w: object frame holds %T", ErrInvalidCheckpoint, f.Val)
+		}
+		if _, ok := f.Val.([]any); f.Kind == frameArray && !ok {
+			return fmt.Errorf("%w: array frame holds %T", ErrInvalidCheckpoint, f.Val)
+		}
+	}
+	return nil
+}
+
+// checkpointUnion OnUnion 路由器进行中元素的可序列化形式
+type checkpointUnion struct {
+	Sub    int               `json:"sub"` // 在 OnUnion 订阅中的序号（按订阅列表顺序）
+	Expr   string            `json:"expr"`
+	Field  string            `json:"field"`
+	Chosen bool              `json:"chosen,omitempty"`
+	Key    string            `json:"key,omitempty"`
+	Buffer []checkpointEvent `json:"buffer,omitempty"`
+}
+
+// checkpointEvent 缓冲事件的可序列化形式
+type checkpointEvent struct {
+	Type     EventType           `json:"type"`
+	Document int                 `json:"document,omitempty"`
+	Path     []PathSegment       `json:"path,omitempty"`
+	Value    *checkpointValue    `json:"value,omitempty"`
+	Missing  *MissingFieldsError `json:"missing,omitempty"`
+	Scope    *checkpointScope    `json:"scope,omitempty"`
+}
+
+// checkpointValue 缓冲事件中部分值的可序列化形式
+type checkpointValue struct {
+	Kind        ValueKind      `json:"kind"`
+	Value       any            `json:"value,omitempty"`
+	Append      bool           `json:"append,omitempty"`
+	Complete    bool           `json:"complete,omitempty"`
+	Policy      CoercionPolicy `json:"policy,omitempty"`
+	Accumulated string         `json:"accumulated,omitempty"`
+}
+
+// checkpointScope 缓冲事件捕获的作用域的可序列化形式
+type checkpointScope struct {
+	Prefix []PathSegment `json:"prefix,omitempty"`
+	Depth  int           `json:"depth"`
+	ID     uint64        `json:"id,omitempty"`
+	Doc    int           `json:"doc"`
+}
+
+// unionSubs 按订阅列表顺序返回 OnUnion 订阅
+func (p *Parser) unionSubs() []*Subscription {
+	var subs []*Subscription
+	for _, sub := range p.subs {
+		if sub.union != nil {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// checkpointUnions 返回各 OnUnion 路由器进行中元素的状态
+func (p *Parser) checkpointUnions() ([]checkpointUnion, error) {
+	var unions []checkpointUnion
+	for i, sub := range p.unionSubs() {
+		s := p.unions[sub.union]
+		if s == nil || !s.active {
+			continue
+		}
+		cu := checkpointUnion{
+			Sub:    i,
+			Expr:   sub.expr,
+			Field:  sub.union.field,
+			Chosen: s.chosen != nil,
+			Key:    s.key,
+		}
+		for _, ev := range s.buffer {
+			ce, err := newCheckpointEvent(ev)
+			if err != nil {
+				return nil, err
+			}
+			cu.Buffer = append(cu.Buffer, ce)
+		}
+		unions = append(unions, cu)
+	}
+	return unions, nil
+}
+
+// newCheckpointEvent 将缓冲的事件转换为可序列化形式
+func newCheckpointEvent(ev Event) (checkpointEvent, error) {
+	ce := checkpointEvent{
+		Type:     ev.Type,
+		Document: ev.Document,
+		Path:     ev.pathSegments,
+	}
+	if v := ev.Value; v != nil {
+		ce.Value = &checkpointValue{
+			Kind:        v.Kind,
+			Value:       v.Value,
+			Append:      v.Append,
+			Complete:    v.Complete,
+			Policy:      v.policy,
+			Accumulated: v.accumulated,
+		}
+	}
+	if ev.Err != nil {
+		mf, ok := ev.Err.(*MissingFieldsError)
+		if !ok {
+			return ce, fmt.Errorf("%w: OnUnion buffer holds %s event with error: %v", ErrInvalidState, ev.Type, ev.Err)
+		}
+		ce.Missing = mf
+	}
+	if sc := ev.scope; sc != nil {
+		ce.Scope = &checkpointScope{
+			Prefix: sc.prefix,
+			Depth:  sc.depth,
+			ID:     sc.id,
+			Doc:    sc.doc,
+		}
+	}
+	return ce, nil
+}
+
+// event 在 p 上还原缓冲的事件
+func (ce checkpointEvent) event(p *Parser) Event {
+	ev := Event{
+		Type:         ce.Type,
+		Document:     ce.Document,
+		pathSegments: ce.Path,
+		syntax:       p.opts.PathSyntax,
+	}
+	if ce.Missing != nil {
+		ev.Err = ce.Missing
+	}
+	if v := ce.Value; v != nil {
+		ev.Value = &PartialValue{
+			Kind:         v.Kind,
+			Value:        v.Value,
+			Append:       v.Append,
+			Complete:     v.Complete,
+			policy:       v.Policy,
+			pathSegments: ce.Path,
+			pathSyntax:   p.opts.PathSyntax,
+			accumulated:  v.Accumulated,
+		}
+		if ev.Value.pathSegments == nil {
+			ev.Value.pathSegments = []PathSegment{}
+		}
+	}
+	if sc := ce.Scope; sc != nil {
+		ev.scope = &Scope{
+			p:      p,
+			prefix: sc.Prefix,
+			depth:  sc.Depth,
+			id:     sc.ID,
+			doc:    sc.Doc,
+		}
+	}
+	return ev
+}
@@ -0,0 +1,226 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// eventLog 记录所有事件的可比较形式
+type eventLog []string
+
+func (l *eventLog) subscribe(p *Parser) {
+	for _, expr := range []string{"$", "$.*", "$[*]", "$.*[*]", "$.*[*].*"} {
+		p.On(expr, l.record)
+	}
+}
+
+func (l *eventLog) record(ev Event) {
+	s := fmt.Sprintf("%d %s %s", ev.Document, ev.Type, ev.Path())
+	if ev.Value != nil {
+		s += fmt.Sprintf(" %s %v append=%v complete=%v acc=%q",
+			ev.Value.Kind, ev.Value.Value, ev.Value.Append, ev.Value.Complete, ev.Value.Accumulated())
+	}
+	*l = append(*l, s)
+}
+
+func TestParser_CheckpointRestore(t *testing.T) {
+	input := []rune(`{"name": "流式 tool", "n": -12.50e3, "ok": true, "tags": ["a", null, {"k": false}], "empty": {}}` +
+		"\n" + `[1, "two"] "tail" 42`)
+
+	configs := map[string][]Option{
+		"default":     nil,
+		"materialize": {WithMaterialize()},
+		"partial":     {WithPartialNumbers(), WithPartialKeys(), WithFlushPolicy(FlushEvery(2))},
+	}
+
+	for name, opts := range configs {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i <= len(input); i++ {
+				head, tail := string(input[:i]), string(input[i:])
+
+				var want eventLog
+				p := NewParser(opts...)
+				want.subscribe(p)
+				if err := p.FeedString(head); err != nil {
+					t.Fatalf("split %d: FeedString() failed: %v", i, err)
+				}
+				if err := p.FeedString(tail); err != nil {
+					t.Fatalf("split %d: FeedString() failed: %v", i, err)
+				}
+				p.Close()
+
+				var got eventLog
+				p1 := NewParser(opts...)
+				got.subscribe(p1)
+				if err := p1.FeedString(head); err != nil {
+					t.Fatalf("split %d: FeedString() failed: %v", i, err)
+				}
+				data, err := p1.Checkpoint()
+				if err != nil {
+					t.Fatalf("split %d: Checkpoint() failed: %v", i, err)
+				}
+
+				p2 := NewParser(opts...)
+				got.subscribe(p2)
+				if err := p2.Restore(data); err != nil {
+					t.Fatalf("split %d: Restore() failed: %v", i, err)
+				}
+				if err := p2.FeedString(tail); err != nil {
+					t.Fatalf("split %d: FeedString() after Restore failed: %v", i, err)
+				}
+				p2.Close()
+
+				if len(got) != len(want) {
+					t.Fatalf("split %d: expected %d events, got %d\nwant: %q\ngot:  %q", i, len(want), len(got), want, got)
+				}
+				for j := range want {
+					if got[j] != want[j] {
+						t.Fatalf("split %d: event %d = %q, want %q", i, j, got[j], want[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParser_CheckpointErrors(t *testing.T) {
+	p := NewParser()
+	p.FeedString(`{"a": 1}}`)
+	if _, err := p.Checkpoint(); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState for failed parser, got %v", err)
+	}
+
+	p = NewParser()
+	p.Close()
+	if _, err := p.Checkpoint(); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState for closed parser, got %v", err)
+	}
+
+	for _, data := range []string{
+		`not json`,
+		`{"version": 99}`,
+		`{"version": 1, "state": 1, "stack": []}`,
+		`{"version": 1, "state": 0, "stack": [{"kind": 0}]}`,
+		`{"version": 1, "state": 1, "stack": [{"kind": 0, "val": [1]}]}`,
+	} {
+		if err := NewParser().Restore([]byte(data)); !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Errorf("Restore(%s): expected ErrInvalidCheckpoint, got %v", data, err)
+		}
+	}
+}
+
+func TestParser_RestoreOptionMismatch(t *testing.T) {
+	p := NewParser()
+	p.FeedString(`{"items": [1, {"a": `)
+	data, err := p.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint() failed: %v", err)
+	}
+
+	for _, opts := range [][]Option{{WithMaterialize()}, {WithPartialNumbers()}, {WithPartialKeys()}, {WithRecovery()}} {
+		q := NewParser(opts...)
+		if err := q.Restore(data); !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Errorf("%s: expected ErrInvalidCheckpoint, got %v", q.Options(), err)
+		}
+		// 被拒绝的检查点不应改变 Parser 的状态
+		if err := q.FeedString(`{"ok": true}`); err != nil {
+			t.Errorf("%s: parser unusable after rejected Restore: %v", q.Options(), err)
+		}
+	}
+
+	m := NewParser(WithMaterialize())
+	m.FeedString(`{"items": [1, {"a": `)
+	data, err = m.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint() failed: %v", err)
+	}
+	if err := NewParser().Restore(data); !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Errorf("expected ErrInvalidCheckpoint restoring a materialized checkpoint, got %v", err)
+	}
+	q := NewParser(WithMaterialize())
+	if err := q.Restore(data); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if err := q.FeedString(`2}]}`); err != nil {
+		t.Errorf("FeedString() after Restore failed: %v", err)
+	}
+}
+
+// checkSplits 在 input 的每个位置创建检查点并恢复到新的 Parser，检查事件序列与不中断解析时一致
+// setup 创建 Parser 并注册记录事件的订阅
+func checkSplits(t *testing.T, input string, setup func(*eventLog) *Parser) {
+	t.Helper()
+	runes := []rune(input)
+	for i := 0; i <= len(runes); i++ {
+		head, tail := string(runes[:i]), string(runes[i:])
+
+		var want eventLog
+		p := setup(&want)
+		p.FeedString(head)
+		p.FeedString(tail)
+		p.Close()
+
+		var got eventLog
+		p1 := setup(&got)
+		p1.FeedString(head)
+		data, err := p1.Checkpoint()
+		if err != nil {
+			t.Fatalf("split %d: Checkpoint() failed: %v", i, err)
+		}
+		p2 := setup(&got)
+		if err := p2.Restore(data); err != nil {
+			t.Fatalf("split %d: Restore() failed: %v", i, err)
+		}
+		p2.FeedString(tail)
+		p2.Close()
+
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("split %d:\nwant: %q\ngot:  %q", i, want, got)
+		}
+	}
+}
+
+// TestParser_CheckpointUnion 测试 OnUnion 进行中的元素（缓冲的事件、选中的 Handler）在恢复后继续路由
+func TestParser_CheckpointUnion(t *testing.T) {
+	input := `[{"body": "a", "n": {"x": 1}, "type": "text", "more": 2}, {"type": "call", "args": [1]}, {"type": "x", "y": 1}, {"z": 1}]`
+	checkSplits(t, input, func(l *eventLog) *Parser {
+		route := func(name string) Handler {
+			return func(ev Event) {
+				l.record(ev)
+				(*l)[len(*l)-1] = name + " " + (*l)[len(*l)-1]
+				if sc := ev.Scope(); sc != nil {
+					(*l)[len(*l)-1] += " scope=" + sc.Path()
+				}
+			}
+		}
+		p := NewParser()
+		p.OnUnion("$[*]", "type", map[string]Handler{
+			"text": route("text"),
+			"call": route("call"),
+		})
+		return p
+	})
+
+	p := NewParser()
+	p.OnUnion("$[*]", "type", map[string]Handler{})
+	p.FeedString(`[{"a": 1`)
+	data, err := p.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint() failed: %v", err)
+	}
+	if err := NewParser().Restore(data); !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Errorf("expected ErrInvalidCheckpoint without a matching OnUnion, got %v", err)
+	}
+}
+
+// TestParser_CheckpointRecovery 测试错误恢复中跳过损坏元素的状态在恢复后继续生效
+func TestParser_CheckpointRecovery(t *testing.T) {
+	input := `[1, {"a": ]}, 3, [}, 5]` + "\n" + `{"b": 2}`
+	checkSplits(t, input, func(l *eventLog) *Parser {
+		p := NewParser(WithRecovery())
+		l.subscribe(p)
+		p.On("$[*].a", l.record)
+		return p
+	})
+}
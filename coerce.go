@@ -0,0 +1,141 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CoercionPolicy 决定 PartialValue 访问器在类型不匹配时是否进行转换
+type CoercionPolicy int
+
+const (
+	// CoercionLenient 宽松模式（默认）：数字/字符串/布尔之间尽量转换，例如 "1" 视为 true
+	CoercionLenient CoercionPolicy = iota
+	// CoercionLLM 面向 LLM 输出的宽松模式：只接受常见的引号包裹，
+	// 例如 "42" 可作为数字，"true"/"yes" 可作为布尔，但数字不会被当作布尔
+	CoercionLLM
+	// CoercionStrict 严格模式：JSON 类型必须与访问器一致
+	CoercionStrict
+)
+
+// String 返回强制转换策略的字符串表示
+func (c CoercionPolicy) String() string {
+	switch c {
+	case CoercionLenient:
+		return "Lenient"
+	case CoercionLLM:
+		return "LLM"
+	case CoercionStrict:
+		return "Strict"
+	default:
+		return fmt.Sprintf("CoercionPolicy(%d)", c)
+	}
+}
+
+// TypeError 表示值的类型无法按当前策略转换为目标类型
+type TypeError struct {
+	Path string    // 值所在路径（手动构造的值可能为空）
+	Want ValueKind // 期望的类型
+	Got  ValueKind // 实际的类型
+}
+
+// Error 实现 error 接口
+func (e *TypeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("stream: cannot use %s value as %s", e.Got, e.Want)
+	}
+	return fmt.Sprintf("stream: cannot use %s value at %s as %s", e.Got, e.Path, e.Want)
+}
+
+// Unwrap 使 errors.Is(err, ErrTypeMismatch) 成立
+func (e *TypeError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// SetCoercion 设置之后发出的所有值使用的强制转换策略
+func (p *Parser) SetCoercion(c CoercionPolicy) {
+	p.opts.Coercion = c
+}
+
+// Coercion 返回当前的强制转换策略
+func (p *Parser) Coercion() CoercionPolicy {
+	return p.opts.Coercion
+}
+
+// Coercion 返回值使用的强制转换策略
+func (pv *PartialValue) Coercion() CoercionPolicy {
+	if pv == nil {
+		return CoercionLenient
+	}
+	return pv.policy
+}
+
+// typeError 构造一个 TypeError
+func (pv *PartialValue) typeError(want ValueKind) error {
+	if pv == nil || pv.Value == nil {
+		return &TypeError{Want: want, Got: ValueNull}
+	}
+	var path string
+	if pv.pathSegments != nil {
+		path = buildPath(pv.pathSegments, pv.pathSyntax)
+	}
+	return &TypeError{Path: path, Want: want, Got: pv.Kind}
+}
+
+// numericValue 按策略返回可用于数字转换的原始值
+func (pv *PartialValue) numericValue() (any, bool) {
+	if pv.Kind == ValueNumber || pv.policy == CoercionLenient {
+		return pv.Value, true
+	}
+	if s, ok := pv.Value.(string); ok && pv.policy == CoercionLLM {
+		return strings.TrimSpace(s), true
+	}
+	return nil, false
+}
+
+// StringE 转换为 string，类型不允许时返回 *TypeError
+func (pv *PartialValue) StringE() (string, error) {
+	if pv == nil || pv.Value == nil {
+		return "", pv.typeError(ValueString)
+	}
+	if pv.Kind != ValueString && pv.policy == CoercionStrict {
+		return "", pv.typeError(ValueString)
+	}
+	return pv.String(), nil
+}
+
+// BoolE 转换为 bool，类型不允许时返回 *TypeError
+func (pv *PartialValue) BoolE() (bool, error) {
+	b, ok := pv.toBool()
+	if !ok {
+		return false, pv.typeError(ValueBool)
+	}
+	return b, nil
+}
+
+// toBool 按策略转换为 bool
+func (pv *PartialValue) toBool() (bool, bool) {
+	if pv == nil || pv.Value == nil {
+		return false, false
+	}
+	if b, ok := pv.Value.(bool); ok {
+		return b, true
+	}
+	switch pv.policy {
+	case CoercionStrict:
+		return false, false
+	case CoercionLLM:
+		s, ok := pv.Value.(string)
+		if !ok {
+			return false, false
+		}
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true", "yes":
+			return true, true
+		case "false", "no":
+			return false, true
+		}
+		return false, false
+	}
+	return pv.lenientBool()
+}
@@ -0,0 +1,128 @@
+package stream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCoercion_Bool(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CoercionPolicy
+		value  *PartialValue
+		want   bool
+		ok     bool
+	}{
+		{"lenient string 1", CoercionLenient, &PartialValue{Kind: ValueString, Value: "1"}, true, true},
+		{"lenient string 0.0", CoercionLenient, &PartialValue{Kind: ValueString, Value: "0.0"}, false, true},
+		{"llm string yes", CoercionLLM, &PartialValue{Kind: ValueString, Value: " Yes "}, true, true},
+		{"llm string 1", CoercionLLM, &PartialValue{Kind: ValueString, Value: "1"}, false, false},
+		{"llm number", CoercionLLM, &PartialValue{Kind: ValueNumber, Value: "1"}, false, false},
+		{"strict bool", CoercionStrict, &PartialValue{Kind: ValueBool, Value: false}, false, true},
+		{"strict string true", CoercionStrict, &PartialValue{Kind: ValueString, Value: "true"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.value.policy = tt.policy
+			got, err := tt.value.BoolE()
+			if tt.ok {
+				if err != nil || got != tt.want {
+					t.Errorf("BoolE() = %v, %v; want %v", got, err, tt.want)
+				}
+				return
+			}
+			var te *TypeError
+			if !errors.As(err, &te) || te.Want != ValueBool || te.Got != tt.value.Kind {
+				t.Errorf("BoolE() error = %v, want *TypeError", err)
+			}
+			if tt.value.Bool() {
+				t.Error("Bool() should return false on type mismatch")
+			}
+		})
+	}
+}
+
+func TestCoercion_Number(t *testing.T) {
+	quoted := &PartialValue{Kind: ValueString, Value: " 42 "}
+
+	quoted.policy = CoercionLLM
+	if got, err := quoted.Int64E(); err != nil || got != 42 {
+		t.Errorf("LLM Int64E() = %v, %v", got, err)
+	}
+	if quoted.Int() != 42 {
+		t.Errorf("LLM Int() = %v, want 42", quoted.Int())
+	}
+
+	quoted.policy = CoercionStrict
+	if _, err := quoted.Int64E(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("strict Int64E() error = %v, want ErrTypeMismatch", err)
+	}
+	if quoted.Int() != 0 {
+		t.Errorf("strict Int() = %v, want 0", quoted.Int())
+	}
+
+	word := &PartialValue{Kind: ValueString, Value: "many", policy: CoercionLLM}
+	if _, err := word.Float64E(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Float64E() error = %v, want ErrTypeMismatch", err)
+	}
+}
+
+func TestCoercion_String(t *testing.T) {
+	num := &PartialValue{Kind: ValueNumber, Value: "42"}
+	if got, err := num.StringE(); err != nil || got != "42" {
+		t.Errorf("lenient StringE() = %q, %v", got, err)
+	}
+	num.policy = CoercionStrict
+	if _, err := num.StringE(); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("strict StringE() error = %v, want ErrTypeMismatch", err)
+	}
+	if num.String() != "42" {
+		t.Errorf("strict String() = %q, want %q", num.String(), "42")
+	}
+}
+
+// TestCoercion_Parser 测试 Parser 上的策略作用于发出的值，且 TypeError 带有路径
+func TestCoercion_Parser(t *testing.T) {
+	var err error
+	p := NewParser()
+	p.SetCoercion(CoercionStrict)
+	p.On("$.items[*].done", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			_, err = ev.Value.BoolE()
+		}
+	})
+	if ferr := p.FeedString(`{"items": [{"done": "1"}]}`); ferr != nil {
+		t.Fatalf("FeedString() failed: %v", ferr)
+	}
+
+	var te *TypeError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TypeError, got %v", err)
+	}
+	if te.Path != "$.items[0].done" || te.Want != ValueBool || te.Got != ValueString {
+		t.Errorf("unexpected TypeError: %+v", te)
+	}
+}
+
+// TestCoercion_StrictFormatting 测试严格策略下 String() 仍能格式化数字、布尔和数字增量
+func TestCoercion_StrictFormatting(t *testing.T) {
+	var got []string
+	p := NewParser()
+	p.SetCoercion(CoercionStrict)
+	p.EnablePartialNumbers()
+	p.On("$[*]", func(ev Event) {
+		if ev.Type == EventFieldValue {
+			got = append(got, ev.Value.String())
+		}
+	})
+	for _, chunk := range []string{`[12`, `34, true]`} {
+		if err := p.FeedString(chunk); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+	if want := []string{"12", "34", "1234", "true"}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
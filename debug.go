@@ -230,6 +230,9 @@ func (d *defaultDebugLogger) formatEvent(event Event) string {
 		valueStr := d.formatPartialValue(event.Value)
 		parts = append(parts, valueStr)
 	}
+	if event.Err != nil {
+		parts = append(parts, fmt.Sprintf("err=%v", event.Err))
+	}
 	return strings.Join(parts, " | ")
 }
 
@@ -0,0 +1,161 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"unicode/utf8"
+)
+
+// readBufferSize 每次从 io.Reader 读取的字节数
+const readBufferSize = 4096
+
+// Decoder 从 io.Reader 按需读取输入并逐个返回事件（拉取式 API）
+// 只在已解析的事件取完时才继续读取，停止迭代即停止读取
+type Decoder struct {
+	r       io.Reader
+	p       *Parser
+	buf     []byte  // 读取缓冲区，末尾可能保留不完整的 UTF-8 字符
+	pending []Event // 已解析尚未返回的事件
+	err     error   // 读取或解析错误，在 pending 取完后返回
+	eof     bool    // 输入已结束，Parser 已关闭
+	last    Event   // 最近一次 Next 返回的事件
+	skip    int     // 正在跳过的子树中未闭合的容器层数
+}
+
+// NewDecoder 创建从 r 读取的 Decoder，opts 与 NewParser 相同，选项非法时 panic
+func NewDecoder(r io.Reader, opts ...Option) *Decoder {
+	d := &Decoder{
+		r:   r,
+		p:   NewParser(opts...),
+		buf: make([]byte, 0, readBufferSize),
+	}
+	d.p.tap = d.collect
+	return d
+}
+
+// Parser 返回底层的 Parser，可用于注册订阅（订阅的 Handler 在 Next 读取输入时被调用）
+func (d *Decoder) Parser() *Parser {
+	return d.p
+}
+
+// collect 接收 Parser 发出的事件，跳过子树期间丢弃事件
+func (d *Decoder) collect(ev Event) {
+	if d.skip > 0 && !d.skipEvent(ev) {
+		return
+	}
+	d.pending = append(d.pending, ev)
+}
+
+// skipEvent 按容器开始/结束事件更新跳过的层数，返回该事件是否需要保留
+func (d *Decoder) skipEvent(ev Event) bool {
+	switch ev.Type {
+	case EventObjectStart, EventArrayStart:
+		d.skip++
+	case EventObjectEnd, EventArrayEnd:
+		d.skip--
+	case EventError, EventDocumentEnd, EventStreamEnd:
+		// 子树被错误恢复丢弃或输入提前结束，之后的事件不再属于该子树
+		d.skip = 0
+		return true
+	}
+	return false
+}
+
+// Next 返回下一个事件，输入结束（EventStreamEnd 已返回）后返回 io.EOF
+func (d *Decoder) Next() (Event, error) {
+	for len(d.pending) == 0 {
+		if d.err != nil {
+			return Event{}, d.err
+		}
+		if d.eof {
+			return Event{}, io.EOF
+		}
+		d.fill()
+	}
+	ev := d.pending[0]
+	d.pending[0] = Event{}
+	d.pending = d.pending[1:]
+	d.last = ev
+	return ev, nil
+}
+
+// Skip 跳过最近一次 Next 返回的 ObjectStart/ArrayStart 对应的整个子树（包括其结束事件），
+// 下一次 Next 返回子树之后的事件；对于其他事件 Skip 不做任何事
+func (d *Decoder) Skip() error {
+	if d.last.Type != EventObjectStart && d.last.Type != EventArrayStart {
+		return nil
+	}
+	d.last = Event{}
+
+	d.skip = 1
+	for len(d.pending) > 0 && d.skip > 0 && !d.skipEvent(d.pending[0]) {
+		d.pending = d.pending[1:]
+	}
+	for d.skip > 0 && d.err == nil && !d.eof {
+		d.fill()
+	}
+	if d.err != nil && len(d.pending) == 0 {
+		return d.err
+	}
+	return nil
+}
+
+// All 返回事件迭代器，可以在 range 中 break 提前结束读取
+// 出错时最后一次迭代的 error 非 nil
+func (d *Decoder) All() iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for {
+			ev, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(ev, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// fill 读取一块输入交给 Parser，输入结束时关闭 Parser
+func (d *Decoder) fill() {
+	n, err := d.r.Read(d.buf[len(d.buf):cap(d.buf)])
+	d.buf = d.buf[:len(d.buf)+n]
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		d.err = err
+		return
+	}
+
+	complete := len(d.buf)
+	if err == nil {
+		complete = fullRunes(d.buf)
+	}
+	if complete > 0 {
+		if perr := d.p.Feed(d.buf[:complete]); perr != nil {
+			d.err = perr
+			return
+		}
+		d.buf = d.buf[:copy(d.buf, d.buf[complete:])]
+	}
+
+	if errors.Is(err, io.EOF) {
+		d.eof = true
+		if cerr := d.p.Close(); cerr != nil {
+			d.err = cerr
+		}
+	}
+}
+
+// fullRunes 返回 b 中完整 UTF-8 字符的字节数，末尾被截断的字符留待下次读取
+func fullRunes(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+			return i
+		}
+	}
+	return len(b)
+}
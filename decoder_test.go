@@ -0,0 +1,113 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder_All(t *testing.T) {
+	r := iotest.OneByteReader(strings.NewReader(`{"text": "你好", "n": 7}`))
+	var got []string
+	for ev, err := range NewDecoder(r).All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s := ev.Type.String() + " " + ev.Path()
+		if ev.Value != nil && ev.Value.Complete {
+			s += "=" + ev.Value.String()
+		}
+		got = append(got, s)
+	}
+
+	want := []string{
+		"DocumentStart ",
+		"ObjectStart ",
+		"FieldValue $.text",
+		"FieldValue $.text",
+		"FieldValue $.text=你好",
+		"FieldValue $.n=7",
+		"ObjectEnd ",
+		"DocumentEnd ",
+		"StreamEnd ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected events:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+// countingReader 记录已读取的字节数
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += n
+	return n, err
+}
+
+func TestDecoder_Break(t *testing.T) {
+	input := "[" + strings.Repeat(`{"id": 1}, `, 10000) + `{"id": 1}]`
+	r := &countingReader{r: strings.NewReader(input)}
+
+	for ev, err := range NewDecoder(r).All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ev.Type == EventFieldValue {
+			break
+		}
+	}
+	if r.n >= len(input) {
+		t.Errorf("expected reading to stop early, read %d of %d bytes", r.n, len(input))
+	}
+}
+
+func TestDecoder_Skip(t *testing.T) {
+	input := `{"skip": {"a": [1, {"b": 2}], "c": "x"}, "list": [[1, 2], 3], "keep": 4}`
+	for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input))} {
+		d := NewDecoder(r)
+		var got []string
+		for {
+			ev, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ev.Type == EventFieldValue || ev.Type == EventArrayItem {
+				got = append(got, ev.Path())
+			}
+			if (ev.Type == EventObjectStart || ev.Type == EventArrayStart) && ev.Path() != "" && ev.Path() != "$.list" {
+				if err := d.Skip(); err != nil {
+					t.Fatalf("Skip() failed: %v", err)
+				}
+			}
+		}
+
+		want := "$.list[0] $.list[1] $.list[1] $.keep"
+		if strings.Join(got, " ") != want {
+			t.Errorf("expected %q, got %q", want, strings.Join(got, " "))
+		}
+	}
+}
+
+func TestDecoder_Error(t *testing.T) {
+	var last error
+	count := 0
+	for _, err := range NewDecoder(strings.NewReader(`{"a": 1}}`)).All() {
+		count++
+		last = err
+	}
+	if !errors.Is(last, ErrMismatchedBrace) {
+		t.Errorf("expected iteration to end with ErrMismatchedBrace, got %v", last)
+	}
+	if count < 2 {
+		t.Errorf("expected events before the error, got %d iterations", count)
+	}
+}
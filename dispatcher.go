@@ -1,12 +1,143 @@
 package stream
 
+import "slices"
+
 // Handler 是事件处理函数类型
 type Handler func(Event)
 
+// HandlerE 是可以返回错误的事件处理函数类型
+// 返回非 nil 错误时解析立即停止，该错误由 Feed 和 Parser.Err 返回；返回 ErrStop 表示主动提前结束，
+// 它不会作为错误报告给观察者和事件通道，通道正常关闭，未完成的 Future 按输入结束处理；
+// 返回 ErrStopPropagation 则只阻止当前事件继续投递给后续订阅者
+type HandlerE func(Event) error
+
+// StringMode 表示 Append 事件向订阅者投递的方式
+type StringMode int
+
+const (
+	// StringDelta 增量模式（默认）：Value 为本次新增的内容，Append 为 true
+	StringDelta StringMode = iota
+	// StringCumulative 累积模式：Value 为截至目前的完整内容，Append 为 false
+	StringCumulative
+)
+
 // Subscription 表示一个订阅
 type Subscription struct {
-	Pattern PathPattern // 编译后的路径模式
-	Handler Handler     // 事件处理函数
+	Pattern  PathPattern // 编译后的路径模式
+	Handler  Handler     // 事件处理函数
+	Mode     StringMode  // Append 事件的投递方式
+	Priority int         // 优先级，越大越先收到事件（默认 0）
+
+	handlerE   HandlerE         // 可返回错误的处理函数（通过 OnE 订阅时使用）
+	expr       string           // 原始路径表达式
+	middleware []Middleware     // 仅作用于该订阅的中间件
+	filter     func(Event) bool // 分发前的过滤条件（nil 表示不过滤）
+	scope      int              // 作用域订阅所属帧的深度 + 1，0 表示普通订阅
+	group      *Group           // 所属的订阅组（nil 表示不属于任何组）
+	subtree    bool             // 是否同时匹配模式下的所有后代路径
+	union      *unionRouter     // OnUnion 订阅的路由器（用于检查点）
+
+	containerPath bool // 容器事件是否按容器自身的路径匹配（见 WithContainerPath）
+}
+
+// SubscribeOption 订阅选项
+type SubscribeOption func(*Subscription)
+
+// WithStringMode 设置订阅接收 Append 事件的方式
+func WithStringMode(mode StringMode) SubscribeOption {
+	return func(s *Subscription) {
+		s.Mode = mode
+	}
+}
+
+// WithPriority 设置订阅的优先级，优先级高的订阅先收到事件，
+// 并可以通过 Event.StopPropagation 或返回 ErrStopPropagation 阻止事件继续投递
+func WithPriority(priority int) SubscribeOption {
+	return func(s *Subscription) {
+		s.Priority = priority
+	}
+}
+
+// WithMiddleware 为订阅添加中间件，位于 Parser.Use 注册的中间件之内
+func WithMiddleware(mw ...Middleware) SubscribeOption {
+	return func(s *Subscription) {
+		s.middleware = append(s.middleware, mw...)
+	}
+}
+
+// WithContainerPath 让订阅按容器自身的路径接收 ObjectEnd/ArrayStart/ArrayEnd，
+// 例如 $.items 收到 items 数组的 ArrayStart/ArrayEnd、$.items[*] 收到每个元素的 ObjectEnd
+// 默认这些事件按发生时的完整路径匹配：ArrayStart/ArrayEnd 发往 $.items[*]，
+// ObjectEnd 发往对象最后一个字段的路径（如 $.items[*].y）；ObjectStart 总是按对象自身的路径匹配
+// OnObjectEnd、OnArrayStart、OnArrayEnd、OnComplete 和 OnUnion 自动使用该选项
+func WithContainerPath() SubscribeOption {
+	return func(s *Subscription) {
+		s.containerPath = true
+	}
+}
+
+// newSubscription 编译路径表达式并创建订阅，表达式非法时 panic
+func newSubscription(expr string, syntax PathSyntax, h Handler, opts []SubscribeOption) *Subscription {
+	pat, err := compilePatternSyntax(expr, syntax)
+	if err != nil {
+		panic(err)
+	}
+	return newPatternSubscription(expr, pat, h, opts)
+}
+
+// newPatternSubscription 使用已编译的路径模式创建订阅
+func newPatternSubscription(expr string, pat PathPattern, h Handler, opts []SubscribeOption) *Subscription {
+	sub := &Subscription{
+		Pattern: pat,
+		Handler: h,
+		expr:    expr,
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	return sub
+}
+
+// addSubscription 按优先级插入订阅，同优先级的订阅保持注册顺序
+func addSubscription(subs []*Subscription, sub *Subscription) []*Subscription {
+	i := len(subs)
+	for i > 0 && subs[i-1].Priority < sub.Priority {
+		i--
+	}
+	return slices.Insert(subs, i, sub)
+}
+
+// deliver 按订阅配置将事件交给 Handler，返回处理函数的错误
+func (s *Subscription) deliver(ev Event) error {
+	return s.call(s.prepare(ev))
+}
+
+// prepare 按订阅配置调整投递的事件
+func (s *Subscription) prepare(ev Event) Event {
+	if s.Mode == StringCumulative && ev.Value != nil && ev.Value.Append {
+		v := *ev.Value
+		v.Value = v.accumulated
+		v.Append = false
+		ev.Value = &v
+	}
+	return ev
+}
+
+// call 调用处理函数，返回其错误
+func (s *Subscription) call(ev Event) error {
+	if s.handlerE != nil {
+		return s.handlerE(ev)
+	}
+	s.Handler(ev)
+	return nil
+}
+
+// matches 判断订阅是否匹配事件路径
+func (s *Subscription) matches(path []PathSegment) bool {
+	if s.subtree && len(path) > len(s.Pattern.Segments) {
+		path = path[:len(s.Pattern.Segments)]
+	}
+	return match(s.Pattern.Segments, path)
 }
 
 func match(pattern []PathSegment, path []PathSegment) bool {
@@ -13,10 +13,44 @@ var (
 	ErrUnclosedString = errors.New("unclosed string")
 	// ErrUnclosedNumber 未闭合的数字
 	ErrUnclosedNumber = errors.New("unclosed number")
+	// ErrUnexpectedEOF 输入在值中途结束（仍有未关闭的容器或未结束的关键字）
+	ErrUnexpectedEOF = errors.New("unexpected end of input")
 	// ErrMismatchedBrace 不匹配的大括号
 	ErrMismatchedBrace = errors.New("mismatched brace")
 	// ErrMismatchedBracket 不匹配的方括号
 	ErrMismatchedBracket = errors.New("mismatched bracket")
 	// ErrUnexpectedCharacter 意外的字符
 	ErrUnexpectedCharacter = errors.New("unexpected character")
+	// ErrMaxDepthExceeded 嵌套深度超出限制
+	ErrMaxDepthExceeded = errors.New("max nesting depth exceeded")
+	// ErrStringTooLong 字符串长度超出限制
+	ErrStringTooLong = errors.New("string too long")
+	// ErrNumberTooLong 数字长度超出限制
+	ErrNumberTooLong = errors.New("number too long")
+	// ErrTooManyKeys 对象 key 数量超出限制
+	ErrTooManyKeys = errors.New("too many object keys")
+	// ErrArrayTooLong 数组长度超出限制
+	ErrArrayTooLong = errors.New("array too long")
+	// ErrInputTooLarge 输入总字节数超出限制
+	ErrInputTooLarge = errors.New("input too large")
+	// ErrInvalidOption 非法的 Parser 选项
+	ErrInvalidOption = errors.New("invalid option")
+	// ErrTypeMismatch 值的类型与访问方式不匹配（见 TypeError）
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrNotNumber 值不是合法的数字
+	ErrNotNumber = errors.New("value is not a number")
+	// ErrNumberOverflow 数字超出目标类型的范围
+	ErrNumberOverflow = errors.New("number out of range")
+	// ErrNotInteger 数字不是整数
+	ErrNotInteger = errors.New("number is not an integer")
+	// ErrPrecisionLoss 转换会丢失精度
+	ErrPrecisionLoss = errors.New("number loses precision")
+	// ErrStop 由 HandlerE 返回，表示主动提前结束解析
+	ErrStop = errors.New("parsing stopped by handler")
+	// ErrStopPropagation 由 HandlerE 返回，表示事件不再投递给后续订阅者，解析继续
+	ErrStopPropagation = errors.New("event propagation stopped")
+	// ErrFieldMissing 期望的字段未出现（见 Future 和 MissingFieldsError）
+	ErrFieldMissing = errors.New("field missing")
+	// ErrInvalidCheckpoint 检查点数据损坏或版本不兼容
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
 )
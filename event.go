@@ -18,8 +18,20 @@ const (
 	EventFieldValue
 	// EventArrayItem 数组项
 	EventArrayItem
-	// EventStreamEnd 流正常结束
+	// EventStreamEnd 输入结束（由 Close 在根路径上发出，输入在值中途结束时 Err 非 nil）
 	EventStreamEnd
+	// EventDocumentStart 顶层文档开始
+	EventDocumentStart
+	// EventDocumentEnd 顶层文档结束
+	EventDocumentEnd
+	// EventError 解析错误（仅在启用错误恢复时发出）
+	EventError
+	// EventKey 对象 key（需启用字段事件）
+	EventKey
+	// EventFieldStart 对象字段的值开始（需启用字段事件）
+	EventFieldStart
+	// EventMissingFields 对象缺少必需字段（见 Parser.Require）
+	EventMissingFields
 )
 
 // String 返回事件类型的字符串表示
@@ -39,6 +51,18 @@ func (et EventType) String() string {
 		return "ArrayItem"
 	case EventStreamEnd:
 		return "StreamEnd"
+	case EventDocumentStart:
+		return "DocumentStart"
+	case EventDocumentEnd:
+		return "DocumentEnd"
+	case EventError:
+		return "Error"
+	case EventKey:
+		return "Key"
+	case EventFieldStart:
+		return "FieldStart"
+	case EventMissingFields:
+		return "MissingFields"
 	default:
 		return fmt.Sprintf("EventType(%d)", et)
 	}
@@ -48,15 +72,29 @@ func (et EventType) String() string {
 type Event struct {
 	Type         EventType     // 事件类型
 	Value        *PartialValue // 部分值（可能为 nil）
+	Document     int           // 所属文档序号（从 0 开始）
+	Err          error         // 错误信息（EventError、EventMissingFields，以及损坏文档的 EventDocumentEnd 和输入不完整时的 EventStreamEnd）
 	pathSegments []PathSegment // 路径段数组（用于延迟计算Path）
 	pathOpts     pathOptions   // 路径计算选项
+	syntax       PathSyntax    // 路径语法
 	pathCache    string        // 缓存的Path字符串（延迟计算）
+	stop         *bool         // 停止向后续订阅者传播（仅在分发期间有效）
+	parser       *Parser       // 发出事件的 Parser（仅在分发期间有效，用于 Scope）
+	scope        *Scope        // 缓冲后重放的事件在缓冲时捕获的作用域
+}
+
+// StopPropagation 使当前事件不再投递给优先级更低（或同优先级中更晚注册）的订阅者
+// 只在 Handler 执行期间有效，对事件通道和 Decoder 没有影响
+func (ev *Event) StopPropagation() {
+	if ev.stop != nil {
+		*ev.stop = true
+	}
 }
 
 // Path 获取路径字符串（延迟计算）
 func (ev *Event) Path() string {
 	if ev.pathCache == "" && len(ev.pathSegments) > 0 {
-		ev.pathCache = buildPathFromSegments(ev.pathSegments, ev.pathOpts)
+		ev.pathCache = buildPath(ev.pathSegments, ev.syntax)
 	}
 	return ev.pathCache
 }
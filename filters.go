@@ -0,0 +1,85 @@
+package stream
+
+import "slices"
+
+// WithEventTypes 只投递指定类型的事件，过滤在分发时完成
+func WithEventTypes(types ...EventType) SubscribeOption {
+	return withFilter(func(ev Event) bool {
+		return slices.Contains(types, ev.Type)
+	})
+}
+
+// withFilter 为订阅添加过滤条件，多个条件需同时满足
+func withFilter(f func(Event) bool) SubscribeOption {
+	return func(s *Subscription) {
+		if prev := s.filter; prev != nil {
+			s.filter = func(ev Event) bool {
+				return prev(ev) && f(ev)
+			}
+			return
+		}
+		s.filter = f
+	}
+}
+
+// isComplete 判断事件是否携带完整的值
+func isComplete(ev Event) bool {
+	if ev.Value == nil || !ev.Value.Complete {
+		return false
+	}
+	switch ev.Type {
+	case EventFieldValue, EventObjectEnd, EventArrayEnd:
+		return true
+	}
+	return false
+}
+
+// isChunk 判断事件是否为字符串/数字的增量片段
+func isChunk(ev Event) bool {
+	return ev.Type == EventFieldValue && ev.Value != nil && ev.Value.Append
+}
+
+// OnComplete 订阅完整的值：标量在结束时触发一次（Complete 为 true 的 EventFieldValue），
+// 启用 Materialize 时对象/数组在结束时以完整值触发
+func (p *Parser) OnComplete(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), withFilter(isComplete), WithContainerPath())...)
+}
+
+// OnChunk 订阅字符串（以及启用增量数字时的数字）的增量片段，ev.Value.Value 为新增内容
+func (p *Parser) OnChunk(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), withFilter(isChunk))...)
+}
+
+// OnObjectStart 订阅对象开始事件，expr 为对象自身的路径
+func (p *Parser) OnObjectStart(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), WithEventTypes(EventObjectStart))...)
+}
+
+// OnObjectEnd 订阅对象结束事件，expr 为对象自身的路径
+func (p *Parser) OnObjectEnd(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), WithEventTypes(EventObjectEnd), WithContainerPath())...)
+}
+
+// OnArrayStart 订阅数组开始事件，expr 为数组自身的路径
+func (p *Parser) OnArrayStart(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), WithEventTypes(EventArrayStart), WithContainerPath())...)
+}
+
+// OnArrayEnd 订阅数组结束事件，expr 为数组自身的路径
+func (p *Parser) OnArrayEnd(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), WithEventTypes(EventArrayEnd), WithContainerPath())...)
+}
+
+// OnArrayItem 订阅数组元素完成事件，expr 为元素的路径（例如 $.items[*]）
+func (p *Parser) OnArrayItem(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	return p.On(expr, h, append(slices.Clip(opts), WithEventTypes(EventArrayItem))...)
+}
+
+// OnStreamEnd 订阅输入结束事件（由 Close 发出）
+func (p *Parser) OnStreamEnd(h Handler, opts ...SubscribeOption) *Parser {
+	root := "$"
+	if p.opts.PathSyntax == PathJSONPointer {
+		root = ""
+	}
+	return p.On(root, h, append(slices.Clip(opts), WithEventTypes(EventStreamEnd))...)
+}
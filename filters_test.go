@@ -0,0 +1,63 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_FilteredSubscriptions(t *testing.T) {
+	var log []string
+	record := func(name string) Handler {
+		return func(ev Event) {
+			s := name + " " + ev.Path()
+			if ev.Value != nil {
+				s += "=" + ev.Value.String()
+			}
+			log = append(log, s)
+		}
+	}
+
+	p := NewParser()
+	p.OnComplete("$.title", record("complete"))
+	p.OnChunk("$.title", record("chunk"))
+	p.OnObjectStart("$.tasks[*]", record("start"))
+	p.OnObjectEnd("$.tasks[*]", record("end"))
+	p.OnArrayStart("$.tasks", record("array_start"))
+	p.OnArrayEnd("$.tasks", record("array_end"))
+	p.OnArrayItem("$.tasks[*]", record("item"))
+	p.OnStreamEnd(record("stream_end"))
+	p.On("$.tasks[*].id", record("types"), WithEventTypes(EventFieldValue))
+
+	p.FeedString(`{"title": "He`)
+	p.FeedString(`llo", "tasks": [{"id": 1}]}`)
+	p.Close()
+
+	want := []string{
+		"chunk $.title=He",
+		"chunk $.title=llo",
+		"complete $.title=Hello",
+		"array_start $.tasks",
+		"start $.tasks[0]",
+		"types $.tasks[0].id=1",
+		"end $.tasks[0]",
+		"item $.tasks[0]=",
+		"array_end $.tasks",
+		"stream_end ",
+	}
+	if strings.Join(log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected events:\n%s", strings.Join(log, "\n"))
+	}
+}
+
+func TestParser_OnCompleteMaterialize(t *testing.T) {
+	var values []any
+	p := NewParser(WithMaterialize())
+	p.OnComplete("$.items[*]", func(ev Event) {
+		values = append(values, ev.Value.Value)
+	})
+	p.FeedString(`{"items": [1, {"a": true}, [2]]}`)
+
+	if len(values) != 3 {
+		t.Fatalf("expected 3 complete items, got %v", values)
+	}
+}
@@ -0,0 +1,154 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FlushMode 表示字符串增量事件的发出时机
+type FlushMode int
+
+const (
+	// FlushPerFeed 每次 Feed 结束时发出（默认）
+	FlushPerFeed FlushMode = iota
+	// FlushEveryRunes 每累积 N 个字符发出
+	FlushEveryRunes
+	// FlushOnWord 在词边界发出（空白、标点，每个中日韩字符视为一个词）
+	FlushOnWord
+	// FlushOnSentence 在句末标点或换行处发出（含中文全角标点）
+	FlushOnSentence
+	// FlushOnLine 在换行处发出
+	FlushOnLine
+	// FlushOnInterval 距上次发出超过指定间隔时发出
+	FlushOnInterval
+)
+
+// String 返回发出时机的字符串表示
+func (m FlushMode) String() string {
+	switch m {
+	case FlushPerFeed:
+		return "PerFeed"
+	case FlushEveryRunes:
+		return "EveryRunes"
+	case FlushOnWord:
+		return "OnWord"
+	case FlushOnSentence:
+		return "OnSentence"
+	case FlushOnLine:
+		return "OnLine"
+	case FlushOnInterval:
+		return "OnInterval"
+	default:
+		return fmt.Sprintf("FlushMode(%d)", m)
+	}
+}
+
+// FlushPolicy 字符串增量事件的发出策略
+// 除 FlushPerFeed 外，Feed 结束时不会强制发出，剩余内容在下一个边界或字符串结束时发出
+type FlushPolicy struct {
+	Mode     FlushMode     // 发出时机
+	Runes    int           // FlushEveryRunes 的字符数阈值
+	Interval time.Duration // FlushOnInterval 的时间间隔
+}
+
+// FlushEvery 返回每 n 个字符发出一次的策略
+func FlushEvery(n int) FlushPolicy {
+	return FlushPolicy{Mode: FlushEveryRunes, Runes: n}
+}
+
+// FlushEveryInterval 返回按时间间隔发出的策略
+func FlushEveryInterval(d time.Duration) FlushPolicy {
+	return FlushPolicy{Mode: FlushOnInterval, Interval: d}
+}
+
+// validate 检查策略参数是否合法
+func (fp FlushPolicy) validate() error {
+	switch fp.Mode {
+	case FlushPerFeed, FlushOnWord, FlushOnSentence, FlushOnLine:
+		return nil
+	case FlushEveryRunes:
+		if fp.Runes <= 0 {
+			return fmt.Errorf("stream: flush policy %s requires Runes > 0", fp.Mode)
+		}
+		return nil
+	case FlushOnInterval:
+		if fp.Interval <= 0 {
+			return fmt.Errorf("stream: flush policy %s requires Interval > 0", fp.Mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("stream: unknown flush mode %s", fp.Mode)
+}
+
+// SetFlushPolicy 设置字符串增量事件的发出策略
+func (p *Parser) SetFlushPolicy(fp FlushPolicy) error {
+	if err := fp.validate(); err != nil {
+		return err
+	}
+	p.opts.Flush = fp
+	return nil
+}
+
+// FlushPolicy 返回当前的字符串增量事件发出策略
+func (p *Parser) FlushPolicy() FlushPolicy {
+	return p.opts.Flush
+}
+
+// shouldFlush 在追加一个字符串片段后判断是否需要立即发出
+func (p *Parser) shouldFlush(s string) bool {
+	switch p.opts.Flush.Mode {
+	case FlushEveryRunes:
+		p.chunkRunes += utf8.RuneCountInString(s)
+		return p.chunkRunes >= p.opts.Flush.Runes
+	case FlushOnWord:
+		r, _ := utf8.DecodeLastRuneInString(s)
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || isCJK(r)
+	case FlushOnSentence:
+		r, _ := utf8.DecodeLastRuneInString(s)
+		return isSentenceEnd(r)
+	case FlushOnLine:
+		r, _ := utf8.DecodeLastRuneInString(s)
+		return r == '\n'
+	case FlushOnInterval:
+		return p.intervalElapsed()
+	}
+	return false
+}
+
+// clock 返回当前时间，默认为 time.Now
+type clock func() time.Time
+
+// intervalElapsed 判断距上次发出是否已超过间隔，首个片段开始计时
+func (p *Parser) intervalElapsed() bool {
+	now := p.now()
+	if p.lastFlush.IsZero() {
+		p.lastFlush = now
+		return false
+	}
+	return now.Sub(p.lastFlush) >= p.opts.Flush.Interval
+}
+
+// flushAtFeedEnd 判断 Feed 结束时是否发出缓冲的字符串片段
+func (p *Parser) flushAtFeedEnd() bool {
+	switch p.opts.Flush.Mode {
+	case FlushPerFeed:
+		return true
+	case FlushOnInterval:
+		return p.chunkBuffer.Len() > 0 && p.intervalElapsed()
+	}
+	return false
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '.', '!', '?', '\n', '。', '！', '？', '…':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// collectAppends 逐字符输入 json 并收集 $.text 的 Append 片段
+func collectAppends(t *testing.T, fp FlushPolicy, json string) []string {
+	t.Helper()
+	var chunks []string
+	p := NewParser()
+	if err := p.SetFlushPolicy(fp); err != nil {
+		t.Fatalf("SetFlushPolicy() failed: %v", err)
+	}
+	p.On("$.text", func(ev Event) {
+		if ev.Value != nil && ev.Value.Append {
+			chunks = append(chunks, ev.Value.String())
+		}
+	})
+	for _, r := range json {
+		if err := p.FeedString(string(r)); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+	return chunks
+}
+
+func TestFlushPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FlushPolicy
+		json   string
+		want   []string
+	}{
+		{
+			name:   "every runes",
+			policy: FlushEvery(4),
+			json:   `{"text": "abcdefghij"}`,
+			want:   []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:   "word",
+			policy: FlushPolicy{Mode: FlushOnWord},
+			json:   `{"text": "hi there, you"}`,
+			want:   []string{"hi ", "there,", " ", "you"},
+		},
+		{
+			name:   "word cjk",
+			policy: FlushPolicy{Mode: FlushOnWord},
+			json:   `{"text": "你好ok"}`,
+			want:   []string{"你", "好", "ok"},
+		},
+		{
+			name:   "sentence",
+			policy: FlushPolicy{Mode: FlushOnSentence},
+			json:   `{"text": "你好。How are you? Fine"}`,
+			want:   []string{"你好。", "How are you?", " Fine"},
+		},
+		{
+			name:   "line",
+			policy: FlushPolicy{Mode: FlushOnLine},
+			json:   "{\"text\": \"a\nbc\nd\"}",
+			want:   []string{"a\n", "bc\n", "d"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectAppends(t, tt.policy, tt.json)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("chunks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushPolicy_Interval(t *testing.T) {
+	var chunks []string
+	p := NewParser()
+	now := time.Unix(0, 0)
+	p.now = func() time.Time { return now }
+	if err := p.SetFlushPolicy(FlushEveryInterval(20 * time.Millisecond)); err != nil {
+		t.Fatalf("SetFlushPolicy() failed: %v", err)
+	}
+	p.On("$.text", func(ev Event) {
+		if ev.Value != nil && ev.Value.Append {
+			chunks = append(chunks, ev.Value.String())
+		}
+	})
+
+	feed := func(s string) {
+		if err := p.FeedString(s); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+	feed(`{"text": "a`)
+	feed(`b`)
+	if len(chunks) != 0 {
+		t.Fatalf("expected no chunks before interval, got %q", chunks)
+	}
+	now = now.Add(30 * time.Millisecond)
+	feed(`c`)
+	if len(chunks) != 1 || chunks[0] != "abc" {
+		t.Fatalf("expected [abc] after interval, got %q", chunks)
+	}
+	feed(`d"}`)
+	if len(chunks) != 2 || chunks[1] != "d" {
+		t.Errorf("expected remaining chunk at string end, got %q", chunks)
+	}
+}
+
+func TestFlushPolicy_Invalid(t *testing.T) {
+	p := NewParser()
+	if err := p.SetFlushPolicy(FlushEvery(0)); err == nil {
+		t.Error("expected error for FlushEvery(0)")
+	}
+	if err := p.SetFlushPolicy(FlushPolicy{Mode: FlushOnInterval}); err == nil {
+		t.Error("expected error for zero interval")
+	}
+	if p.FlushPolicy().Mode != FlushPerFeed {
+		t.Errorf("invalid policy should not be applied, got %v", p.FlushPolicy().Mode)
+	}
+}
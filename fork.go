@@ -0,0 +1,92 @@
+package stream
+
+import "slices"
+
+// ForkMode 决定 Fork 出的 Parser 如何处理订阅
+type ForkMode int
+
+const (
+	// ForkShareSubscriptions 共享原 Parser 的订阅（Handler 会同时收到两个分支的事件）
+	// 之后在任一 Parser 上新增的订阅互不影响
+	ForkShareSubscriptions ForkMode = iota
+	// ForkDetachSubscriptions 不带任何订阅，由调用方为分支单独订阅
+	ForkDetachSubscriptions
+)
+
+// Fork 返回一个与当前解析状态完全相同的独立 Parser（结构帧栈、tokenizer 状态、未完成的缓冲区、错误等），
+// 可以为其输入不同的后续内容，用于推测解码等场景；两个 Parser 之后的解析互不影响
+// 选项、观察者和中间件会被继承，订阅按 mode 共享或分离
+func (p *Parser) Fork(mode ForkMode) *Parser {
+	var subs []*Subscription
+	scoped := 0
+	if mode == ForkShareSubscriptions {
+		subs = make([]*Subscription, len(p.subs), len(p.subs)+4)
+		copy(subs, p.subs)
+		scoped = p.scoped
+	} else {
+		subs = make([]*Subscription, 0, 8)
+	}
+
+	f := newParser(p.opts, subs)
+	f.ob = p.ob
+	f.middleware = append([]Middleware(nil), p.middleware...)
+	f.required = slices.Clip(p.required)
+	f.scoped = scoped
+	if mode == ForkShareSubscriptions {
+		f.unions = p.unions.clone(f)
+	}
+	f.containers = p.containers
+
+	f.stack = make(stack, len(p.stack), cap(p.stack))
+	for i, fr := range p.stack {
+		fr.val = cloneValue(fr.val)
+		fr.need = slices.Clone(fr.need)
+		f.stack[i] = fr
+	}
+	f.state = p.state
+	f.curValueKind = p.curValueKind
+	f.curString.WriteString(p.curString.String())
+	f.curNumber.WriteString(p.curNumber.String())
+	f.numberFlushed = p.numberFlushed
+	f.keyFlushed = p.keyFlushed
+	f.chunkBuffer.WriteString(p.chunkBuffer.String())
+	f.chunkRunes = p.chunkRunes
+	f.lastFlush = p.lastFlush
+	f.now = p.now
+	f.lastValueKind = p.lastValueKind
+	f.err = p.err
+	f.segmentsDirty = true
+	f.bytes = p.bytes
+	f.skipping = p.skipping
+	f.skipDepth = p.skipDepth
+	f.curToken = p.curToken
+	f.docIndex = p.docIndex
+	f.inDocument = p.inDocument
+	f.closed = p.closed
+	f.rootValue = cloneValue(p.rootValue)
+
+	f.tokenizer.state = p.tokenizer.state
+	f.tokenizer.buf = append(f.tokenizer.buf[:0], p.tokenizer.buf...)
+	f.tokenizer.n = p.tokenizer.n
+	f.tokenizer.err = p.tokenizer.err
+	return f
+}
+
+// cloneValue 深拷贝构建中的值，避免两个分支共享可变的 map/slice
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = cloneValue(item)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v), cap(v))
+		for i, item := range v {
+			s[i] = cloneValue(item)
+		}
+		return s
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParser_Fork(t *testing.T) {
+	var answers []string
+	p := NewParser()
+	p.On("$.answer", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			answers = append(answers, ev.Value.String())
+		}
+	})
+	if err := p.FeedString(`{"answer": "Hel`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	shared := p.Fork(ForkShareSubscriptions)
+	detached := p.Fork(ForkDetachSubscriptions)
+	var detachedAnswer string
+	detached.On("$.answer", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			detachedAnswer = ev.Value.String()
+		}
+	})
+
+	if err := p.FeedString(`lo"}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if err := shared.FeedString(`p!"}`); err != nil {
+		t.Fatalf("FeedString() on fork failed: %v", err)
+	}
+	if err := detached.FeedString(`icopter"}`); err != nil {
+		t.Fatalf("FeedString() on fork failed: %v", err)
+	}
+
+	if fmt.Sprint(answers) != "[Hello Help!]" {
+		t.Errorf("expected shared handler to see both branches, got %v", answers)
+	}
+	if detachedAnswer != "Helicopter" {
+		t.Errorf("expected detached fork to parse its own continuation, got %q", detachedAnswer)
+	}
+}
+
+func TestParser_ForkMaterialize(t *testing.T) {
+	p := NewParser(WithMaterialize())
+	if err := p.FeedString(`{"items": [1, {"a": `); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	f := p.Fork(ForkDetachSubscriptions)
+
+	var got, gotFork any
+	p.On("$", func(ev Event) {
+		if ev.Type == EventDocumentEnd {
+			got = ev.Value.Value
+		}
+	})
+	f.On("$", func(ev Event) {
+		if ev.Type == EventDocumentEnd {
+			gotFork = ev.Value.Value
+		}
+	})
+
+	p.FeedString(`2}]}`)
+	f.FeedString(`"x"}, 3]}`)
+
+	if fmt.Sprint(got) != "map[items:[1 map[a:2]]]" {
+		t.Errorf("unexpected original value: %v", got)
+	}
+	if fmt.Sprint(gotFork) != "map[items:[1 map[a:x] 3]]" {
+		t.Errorf("unexpected forked value: %v", gotFork)
+	}
+}
+
+func TestParser_ForkEquivalence(t *testing.T) {
+	input := []rune(`{"s": "ab\"c", "n": 1e5, "k": [true, false, null]} 7`)
+	for i := 0; i <= len(input); i++ {
+		head, tail := string(input[:i]), string(input[i:])
+
+		var want, got eventLog
+		p := NewParser(WithPartialNumbers(), WithPartialKeys())
+		p.FeedString(head)
+		f := p.Fork(ForkDetachSubscriptions)
+		want.subscribe(p)
+		got.subscribe(f)
+		p.FeedString(tail)
+		p.Close()
+		f.FeedString(tail)
+		f.Close()
+
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("split %d: fork events differ\nwant: %q\ngot:  %q", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Future 表示某个路径上第一个完整值的异步结果
+// 可以在另一个 goroutine 中等待，同时主循环继续 Feed
+type Future struct {
+	expr    string
+	pattern PathPattern
+	done    chan struct{}
+	val     *PartialValue
+	err     error
+}
+
+// Future 返回 expr 匹配路径上第一个完整值（见 OnComplete）的 Future
+// 对象/数组在结束时完成；未启用 Materialize 时结果的 Value 为 nil，只表示容器已完整出现
+// 解析出错时以该错误失败，输入结束（Close 或 Reset）时仍未出现则以 ErrFieldMissing 失败
+// 可以在 Feed 进行中从其他 goroutine 调用，此时只能观察到之后发出的值；表达式非法时 panic
+func (p *Parser) Future(expr string) *Future {
+	pat, err := compilePatternSyntax(expr, p.opts.PathSyntax)
+	if err != nil {
+		panic(err)
+	}
+	f := &Future{
+		expr:    expr,
+		pattern: pat,
+		done:    make(chan struct{}),
+	}
+	p.futureMu.Lock()
+	defer p.futureMu.Unlock()
+	if p.futuresDone {
+		f.settle(nil, p.futureError(f))
+		return f
+	}
+	p.newFutures = append(p.newFutures, f)
+	p.hasNewFutures.Store(true)
+	return f
+}
+
+// Await 等待 expr 匹配路径上的第一个完整值，相当于 p.Future(expr).Wait(ctx)
+// 调用方应在另一个 goroutine 中继续 Feed
+func Await(ctx context.Context, p *Parser, expr string) (*PartialValue, error) {
+	return p.Future(expr).Wait(ctx)
+}
+
+// Done 返回在 Future 完成（成功或失败）时关闭的通道
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait 等待 Future 完成，ctx 结束时返回 ctx 的错误
+func (f *Future) Wait(ctx context.Context) (*PartialValue, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+// settle 完成 Future
+func (f *Future) settle(val *PartialValue, err error) {
+	f.val = val
+	f.err = err
+	close(f.done)
+}
+
+// resolveFutures 用事件的完整值完成匹配的 Future
+func (p *Parser) resolveFutures(ev Event, segments []PathSegment) {
+	p.adoptFutures()
+	if len(p.futures) == 0 {
+		return
+	}
+	val := futureValue(ev)
+	if val == nil {
+		return
+	}
+	p.futures = slices.DeleteFunc(p.futures, func(f *Future) bool {
+		if !match(f.pattern.Segments, segments) {
+			return false
+		}
+		f.settle(val, nil)
+		return true
+	})
+}
+
+// futureValue 返回事件可用于完成 Future 的值，不是完整值时返回 nil
+// 未启用 Materialize 时对象/数组的结束事件不携带值，此时以 Value 为 nil 的完整值完成
+func futureValue(ev Event) *PartialValue {
+	if isComplete(ev) {
+		return ev.Value
+	}
+	switch ev.Type {
+	case EventObjectEnd:
+		return &PartialValue{Kind: ValueObject, Complete: true}
+	case EventArrayEnd:
+		return &PartialValue{Kind: ValueArray, Complete: true}
+	}
+	return nil
+}
+
+// adoptFutures 使其他 goroutine 新注册的 Future 生效
+func (p *Parser) adoptFutures() {
+	if !p.hasNewFutures.Load() {
+		return
+	}
+	p.futureMu.Lock()
+	p.futures = append(p.futures, p.newFutures...)
+	clear(p.newFutures)
+	p.newFutures = p.newFutures[:0]
+	p.hasNewFutures.Store(false)
+	p.futureMu.Unlock()
+}
+
+// rejectFutures 以 err 使所有未完成的 Future 失败（err 为 nil 时使用 ErrFieldMissing），
+// 之后注册的 Future 立即以同样的错误失败，直到 Reset
+func (p *Parser) rejectFutures(err error) {
+	p.futureMu.Lock()
+	pending := slices.Concat(p.futures, p.newFutures)
+	clear(p.newFutures)
+	p.newFutures = p.newFutures[:0]
+	p.hasNewFutures.Store(false)
+	p.futuresDone = true
+	p.futureErr = err
+	for _, f := range pending {
+		f.settle(nil, p.futureError(f))
+	}
+	p.futureMu.Unlock()
+
+	clear(p.futures)
+	p.futures = p.futures[:0]
+}
+
+// futureError 返回解析结束后 Future 失败的原因
+func (p *Parser) futureError(f *Future) error {
+	if p.futureErr != nil {
+		return p.futureErr
+	}
+	return fmt.Errorf("%w: %s", ErrFieldMissing, f.expr)
+}
+
+// reopenFutures 在 Reset 后允许新的 Future 等待下一个流
+func (p *Parser) reopenFutures() {
+	p.futureMu.Lock()
+	p.futuresDone = false
+	p.futureErr = nil
+	p.futureMu.Unlock()
+}
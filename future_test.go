@@ -0,0 +1,126 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAwait(t *testing.T) {
+	p := NewParser()
+	title := p.Future("$.title")
+	p.FeedString(" ") // 使 title 生效
+
+	fed := make(chan error, 1)
+	go func() {
+		// 等待 Await 注册后再输入 body 所在的片段
+		for !p.hasNewFutures.Load() {
+			time.Sleep(time.Millisecond)
+		}
+		for _, chunk := range []string{`{"status": "run`, `ning", "title": "Hel`, `lo", "body": "..."}`} {
+			if err := p.FeedString(chunk); err != nil {
+				fed <- err
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		fed <- p.Close()
+	}()
+
+	v, err := Await(context.Background(), p, "$.body")
+	if err != nil {
+		t.Fatalf("Await() failed: %v", err)
+	}
+	if v.String() != "..." {
+		t.Errorf("expected body %q, got %q", "...", v.String())
+	}
+
+	v, err = title.Wait(context.Background())
+	if err != nil || v.String() != "Hello" {
+		t.Errorf("expected title %q, got %v (%v)", "Hello", v, err)
+	}
+	if err := <-fed; err != nil {
+		t.Fatalf("feeding failed: %v", err)
+	}
+}
+
+func TestFuture_Reject(t *testing.T) {
+	p := NewParser()
+	missing := p.Future("$.missing")
+	broken := p.Future("$.b")
+	p.FeedString(`{"a": 1, "b": ]`)
+
+	if _, err := broken.Wait(context.Background()); err == nil || !errors.Is(err, p.Err()) {
+		t.Errorf("expected parse error, got %v", err)
+	}
+	if _, err := missing.Wait(context.Background()); err == nil || !errors.Is(err, p.Err()) {
+		t.Errorf("expected parse error, got %v", err)
+	}
+
+	p = NewParser()
+	f := p.Future("$.x")
+	p.FeedString(`{"y": 1}`)
+	p.Close()
+	select {
+	case <-f.Done():
+	default:
+		t.Fatal("expected future to be settled at Close")
+	}
+	if _, err := f.Wait(context.Background()); !errors.Is(err, ErrFieldMissing) {
+		t.Errorf("expected ErrFieldMissing, got %v", err)
+	}
+	if _, err := p.Future("$.y").Wait(context.Background()); !errors.Is(err, ErrFieldMissing) {
+		t.Errorf("expected future registered after Close to fail, got %v", err)
+	}
+}
+
+func TestFuture_WaitContext(t *testing.T) {
+	p := NewParser()
+	f := p.Future("$.never")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// Reset 之后可以等待下一个流
+	p.Reset()
+	g := p.Future("$.v")
+	p.FeedString(`{"v": 42}`)
+	if v, err := g.Wait(context.Background()); err != nil || v.Int() != 42 {
+		t.Errorf("expected 42 after Reset, got %v (%v)", v, err)
+	}
+}
+
+func TestFuture_Containers(t *testing.T) {
+	for _, materialize := range []bool{false, true} {
+		p := NewParser()
+		if materialize {
+			p = NewParser(WithMaterialize())
+		}
+		obj := p.Future("$.obj")
+		arr := p.Future("$.arr")
+		if err := p.FeedString(`{"obj": {"x": 1}, "arr": [1, 2]}`); err != nil {
+			t.Fatalf("Feed failed: %v", err)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		v, err := obj.Wait(context.Background())
+		if err != nil || v.Kind != ValueObject || !v.Complete {
+			t.Fatalf("materialize=%v: expected object, got %v (%v)", materialize, v, err)
+		}
+		if got := v.Value != nil; got != materialize {
+			t.Errorf("materialize=%v: unexpected object value %v", materialize, v.Value)
+		}
+		v, err = arr.Wait(context.Background())
+		if err != nil || v.Kind != ValueArray || !v.Complete {
+			t.Fatalf("materialize=%v: expected array, got %v (%v)", materialize, v, err)
+		}
+		if got := v.Value != nil; got != materialize {
+			t.Errorf("materialize=%v: unexpected array value %v", materialize, v.Value)
+		}
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/codeforgee/stream
 
-go 1.21
+go 1.23
@@ -0,0 +1,72 @@
+package stream
+
+import "fmt"
+
+// Group 是共享路径前缀的一组订阅，组内的表达式相对于前缀
+// 组级中间件作用于组内（包括嵌套组）的所有订阅，位于 Parser.Use 注册的中间件之内
+type Group struct {
+	p          *Parser
+	parent     *Group
+	prefix     []PathSegment // 编译后的前缀模式
+	expr       string        // 前缀表达式
+	middleware []Middleware  // 组级中间件
+}
+
+// Group 创建以 prefix 为前缀的订阅组，prefix 使用 Parser 的路径语法（可以包含通配符），非法时 panic
+func (p *Parser) Group(prefix string) *Group {
+	pat, err := compilePatternSyntax(prefix, p.opts.PathSyntax)
+	if err != nil {
+		panic(err)
+	}
+	return &Group{
+		p:      p,
+		prefix: pat.Segments,
+		expr:   prefix,
+	}
+}
+
+// Group 创建嵌套的订阅组，prefix 相对于当前组（如 .sections[*]）
+func (g *Group) Group(prefix string) *Group {
+	pat, err := compileRelative(g.prefix, prefix, g.p.opts.PathSyntax)
+	if err != nil {
+		panic(fmt.Errorf("group %s: %w", g.expr, err))
+	}
+	return &Group{
+		p:      g.p,
+		parent: g,
+		prefix: pat.Segments,
+		expr:   g.expr + prefix,
+	}
+}
+
+// Use 注册组级中间件，作用于组内所有订阅（包括之前已注册的订阅），先注册的位于外层
+func (g *Group) Use(mw ...Middleware) *Group {
+	g.middleware = append(g.middleware, mw...)
+	g.p.chains = nil
+	return g
+}
+
+// On 订阅相对于组前缀的路径（如 .sections[*].title），空字符串表示前缀本身
+func (g *Group) On(expr string, h Handler, opts ...SubscribeOption) *Group {
+	g.p.subscribe(g.newSubscription(expr, h, opts))
+	return g
+}
+
+// OnE 与 On 相同，但处理函数可以返回错误（见 HandlerE）
+func (g *Group) OnE(expr string, h HandlerE, opts ...SubscribeOption) *Group {
+	sub := g.newSubscription(expr, nil, opts)
+	sub.handlerE = h
+	g.p.subscribe(sub)
+	return g
+}
+
+// newSubscription 编译相对路径并创建属于该组的订阅，表达式非法时 panic
+func (g *Group) newSubscription(expr string, h Handler, opts []SubscribeOption) *Subscription {
+	pat, err := compileRelative(g.prefix, expr, g.p.opts.PathSyntax)
+	if err != nil {
+		panic(fmt.Errorf("group %s: %w", g.expr, err))
+	}
+	sub := newPatternSubscription(g.expr+expr, pat, h, opts)
+	sub.group = g
+	return sub
+}
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_Group(t *testing.T) {
+	var log []string
+	record := func(ev Event) {
+		if ev.Type == EventFieldValue {
+			log = append(log, ev.Path()+"="+ev.Value.String())
+		}
+	}
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ev Event) {
+				if ev.Type == EventFieldValue {
+					log = append(log, name)
+				}
+				next(ev)
+			}
+		}
+	}
+
+	p := NewParser().Use(tag("parser"))
+	report := p.Group("$.response.data.report")
+	report.On(".title", record)
+	sections := report.Group(".sections[*]")
+	sections.On(".title", record)
+	sections.Use(tag("sections"))
+	// 组级中间件对之前注册的订阅同样生效
+	report.Use(tag("report"))
+
+	input := `{"response": {"data": {"report": {"title": "R", "sections": [{"title": "S1"}, {"title": "S2"}]}}}}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []string{
+		"parser", "report", "$.response.data.report.title=R",
+		"parser", "report", "sections", "$.response.data.report.sections[0].title=S1",
+		"parser", "report", "sections", "$.response.data.report.sections[1].title=S2",
+	}
+	if strings.Join(log, " ") != strings.Join(want, " ") {
+		t.Errorf("unexpected events:\n%s", strings.Join(log, " "))
+	}
+}
+
+func TestParser_GroupPointer(t *testing.T) {
+	var got []string
+	p := NewParser(WithPathSyntax(PathJSONPointer))
+	p.Group("/data").Group("/items/*").OnE("/id", func(ev Event) error {
+		got = append(got, ev.Path())
+		return nil
+	}, WithEventTypes(EventFieldValue))
+
+	p.FeedString(`{"data": {"items": [{"id": 1}, {"id": 2}]}}`)
+	if strings.Join(got, " ") != "/data/items/0/id /data/items/1/id" {
+		t.Errorf("unexpected paths: %v", got)
+	}
+}
+
+func TestParser_GroupInvalidPattern(t *testing.T) {
+	g := NewParser().Group("$.a")
+	for _, expr := range []string{"$.b", "b", ".b["} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for relative pattern %q", expr)
+				}
+			}()
+			g.On(expr, func(Event) {})
+		}()
+	}
+}
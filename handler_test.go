@@ -0,0 +1,133 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParser_HandlerStop(t *testing.T) {
+	var seen []string
+	p := NewParser()
+	p.OnE("$.items[*]", func(ev Event) error {
+		if ev.Type != EventFieldValue || !ev.Value.Complete {
+			return nil
+		}
+		seen = append(seen, ev.Value.String())
+		if strings.Contains(ev.Value.String(), "forbidden") {
+			return ErrStop
+		}
+		return nil
+	})
+	var after bool
+	p.On("$.after", func(Event) { after = true })
+
+	err := p.FeedString(`{"items": ["ok", "forbidden", "more"], "after": 1}`)
+	if !errors.Is(err, ErrStop) {
+		t.Fatalf("expected ErrStop, got %v", err)
+	}
+	if !errors.Is(p.Err(), ErrStop) {
+		t.Errorf("expected Err() to return ErrStop, got %v", p.Err())
+	}
+	if strings.Join(seen, ",") != "ok,forbidden" || after {
+		t.Errorf("expected parsing to stop at the violation, seen %v after=%v", seen, after)
+	}
+	if err := p.FeedString(`{}`); !errors.Is(err, ErrStop) {
+		t.Errorf("expected later Feed to keep returning ErrStop, got %v", err)
+	}
+}
+
+func TestParser_HandlerError(t *testing.T) {
+	errPolicy := errors.New("policy violation")
+	p := NewParser()
+	p.OnE("$.answer", func(ev Event) error {
+		if ev.Value != nil && strings.Contains(ev.Value.Accumulated(), "secret") {
+			return errPolicy
+		}
+		return nil
+	})
+
+	// 错误在 Feed 末尾发出的增量事件中返回
+	if err := p.FeedString(`{"answer": "the sec`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if err := p.FeedString(`ret is`); !errors.Is(err, errPolicy) {
+		t.Fatalf("expected policy error, got %v", err)
+	}
+	if err := p.Close(); !errors.Is(err, errPolicy) {
+		t.Errorf("expected Close() to return policy error, got %v", err)
+	}
+}
+
+func TestParser_FeedContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	count := 0
+	p := NewParser()
+	p.On("$[*]", func(ev Event) {
+		if ev.Type == EventFieldValue {
+			count++
+			cancel()
+		}
+	})
+
+	input := "[" + strings.Repeat("1, ", 10000) + "1]"
+	if err := p.FeedStringContext(ctx, input); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if count == 0 || count > ctxCheckInterval {
+		t.Errorf("expected parsing to stop soon after cancel, got %d items", count)
+	}
+	if err := p.FeedContext(context.Background(), []byte("1]")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected parser to stay stopped, got %v", err)
+	}
+}
+
+func TestParser_ReadFrom(t *testing.T) {
+	input := `{"text": "流式解析"}`
+	var text string
+	p := NewParser()
+	p.On("$.text", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			text = ev.Value.String()
+		}
+	})
+
+	n, err := p.ReadFrom(iotest.OneByteReader(strings.NewReader(input)))
+	if err != nil {
+		t.Fatalf("ReadFrom() failed: %v", err)
+	}
+	if n != int64(len(input)) || text != "流式解析" {
+		t.Errorf("expected %d bytes and %q, got %d and %q", len(input), "流式解析", n, text)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewParser().ReadFromContext(ctx, strings.NewReader(input)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParser_ErrStopIsCleanEnd(t *testing.T) {
+	rec := &errorRecorder{}
+	p := NewParser(WithObserver(rec))
+	ch := p.Events(context.Background())
+	missing := p.Future("$.missing")
+	p.OnE("$.a", func(Event) error { return ErrStop })
+
+	if err := p.FeedString(`{"a": 1, "missing": 2}`); !errors.Is(err, ErrStop) {
+		t.Fatalf("expected ErrStop, got %v", err)
+	}
+	if len(rec.errs) != 0 {
+		t.Errorf("ErrStop must not be reported to the observer, got %v", rec.errs)
+	}
+	for ev := range ch {
+		if ev.Type == EventError {
+			t.Errorf("channel received EventError %v after ErrStop", ev.Err)
+		}
+	}
+	if _, err := missing.Wait(context.Background()); !errors.Is(err, ErrFieldMissing) {
+		t.Errorf("expected pending Future to end with ErrFieldMissing, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package stream
+
+import "fmt"
+
+// Limits 解析资源限制，字段为 0 表示不限制
+// 超出限制的错误总是致命的，不会被错误恢复吞掉
+type Limits struct {
+	MaxDepth        int // 最大嵌套深度
+	MaxStringLength int // 单个字符串（含 key）的最大字节数
+	MaxNumberLength int // 单个数字的最大字节数
+	MaxObjectKeys   int // 单个对象的最大 key 数量
+	MaxArrayLength  int // 单个数组的最大元素数量
+	MaxBytes        int // 输入总字节数上限
+}
+
+// validate 检查限制是否合法
+func (l Limits) validate() error {
+	if l.MaxDepth < 0 || l.MaxStringLength < 0 || l.MaxNumberLength < 0 ||
+		l.MaxObjectKeys < 0 || l.MaxArrayLength < 0 || l.MaxBytes < 0 {
+		return fmt.Errorf("stream: limits must not be negative: %+v", l)
+	}
+	return nil
+}
+
+// SetLimits 设置资源限制，同时作用于 Parser 和内部的 Tokenizer
+func (p *Parser) SetLimits(l Limits) {
+	p.opts.Limits = l
+	p.tokenizer.SetLimits(l)
+}
+
+// Limits 返回当前的资源限制
+func (p *Parser) Limits() Limits {
+	return p.opts.Limits
+}
+
+// abort 记录一个致命错误，不受错误恢复影响
+func (p *Parser) abort(err error, context DebugContext) {
+	p.ob.OnError(err, context)
+	p.err = err
+	p.failSinks(err)
+	p.rejectFutures(err)
+}
+
+// halt 因 Handler 返回 ErrStop 而主动结束解析：不作为错误报告，
+// 事件通道在发出已缓冲的事件后正常关闭，未完成的 Future 按输入结束处理（ErrFieldMissing）
+func (p *Parser) halt(err error) {
+	p.err = err
+	p.endSinks()
+	p.rejectFutures(nil)
+}
+
+// checkLimits 在 token 被处理前检查结构相关的限制
+func (p *Parser) checkLimits(tt TokenType) error {
+	l := &p.opts.Limits
+	switch tt {
+	case TokenLBrace, TokenLBracket:
+		if l.MaxDepth > 0 && len(p.stack) >= l.MaxDepth {
+			return fmt.Errorf("%w: limit %d", ErrMaxDepthExceeded, l.MaxDepth)
+		}
+	case TokenStringEnd:
+		if p.state == pObjExpectKey && l.MaxObjectKeys > 0 {
+			if top := p.stack.top(); top != nil && top.keys >= l.MaxObjectKeys {
+				return fmt.Errorf("%w: limit %d", ErrTooManyKeys, l.MaxObjectKeys)
+			}
+		}
+	}
+	// 宽松语法下缺少逗号的元素同样会被接受，因此两种数组状态下都要计数
+	if l.MaxArrayLength > 0 && (p.state == pArrExpectValue || p.state == pArrAfterValue) &&
+		p.curValueKind == valNone && isValueStart(tt) {
+		if top := p.stack.top(); top != nil && top.index >= l.MaxArrayLength {
+			return fmt.Errorf("%w: limit %d", ErrArrayTooLong, l.MaxArrayLength)
+		}
+	}
+	return nil
+}
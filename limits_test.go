@@ -0,0 +1,133 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestLimits 测试各类资源限制
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		input  string
+		want   error
+	}{
+		{
+			name:   "depth ok",
+			limits: Limits{MaxDepth: 2},
+			input:  `{"a": [1]}`,
+		},
+		{
+			name:   "depth exceeded",
+			limits: Limits{MaxDepth: 2},
+			input:  `{"a": [{"b": 1}]}`,
+			want:   ErrMaxDepthExceeded,
+		},
+		{
+			name:   "string too long",
+			limits: Limits{MaxStringLength: 4},
+			input:  `{"a": "hello"}`,
+			want:   ErrStringTooLong,
+		},
+		{
+			name:   "key too long",
+			limits: Limits{MaxStringLength: 4},
+			input:  `{"hello": 1}`,
+			want:   ErrStringTooLong,
+		},
+		{
+			name:   "number too long",
+			limits: Limits{MaxNumberLength: 3},
+			input:  `{"a": 12345}`,
+			want:   ErrNumberTooLong,
+		},
+		{
+			name:   "too many keys",
+			limits: Limits{MaxObjectKeys: 2},
+			input:  `{"a": 1, "b": 2, "c": 3}`,
+			want:   ErrTooManyKeys,
+		},
+		{
+			name:   "array length ok",
+			limits: Limits{MaxArrayLength: 3},
+			input:  `["a", 2, {}]`,
+		},
+		{
+			name:   "array too long",
+			limits: Limits{MaxArrayLength: 3},
+			input:  `["a", 2, {}, []]`,
+			want:   ErrArrayTooLong,
+		},
+		{
+			name:   "input too large",
+			limits: Limits{MaxBytes: 8},
+			input:  `{"a": "hello"}`,
+			want:   ErrInputTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser()
+			p.SetLimits(tt.limits)
+			err := p.FeedString(tt.input)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestLimits_NotRecoverable 测试超出限制的错误不会被错误恢复吞掉
+func TestLimits_NotRecoverable(t *testing.T) {
+	p := NewParser()
+	p.EnableRecovery()
+	p.SetLimits(Limits{MaxStringLength: 16})
+
+	err := p.FeedString(`["ok", "` + strings.Repeat("x", 32) + `", "next"]`)
+	if !errors.Is(err, ErrStringTooLong) {
+		t.Fatalf("expected ErrStringTooLong, got %v", err)
+	}
+	if !errors.Is(p.Err(), ErrStringTooLong) {
+		t.Errorf("expected Err() to report ErrStringTooLong, got %v", p.Err())
+	}
+}
+
+// TestLimits_Lenient 测试宽松语法下缺少分隔符的输入不能绕过限制
+func TestLimits_Lenient(t *testing.T) {
+	for _, input := range []string{`[1 2 3 4 5]`, `["a" "b" "c"]`, `[{} [] true]`} {
+		p := NewParser(WithLimits(Limits{MaxArrayLength: 2}))
+		if err := p.FeedString(input); !errors.Is(err, ErrArrayTooLong) {
+			t.Errorf("%s: expected ErrArrayTooLong, got %v", input, err)
+		}
+	}
+
+	// 不接受值的位置上的字符串被丢弃，不会累积到后续的值中
+	p := NewParser()
+	var values []string
+	record := func(ev Event) {
+		values = append(values, ev.Path()+"="+fmt.Sprint(ev.Value.Value))
+	}
+	p.OnComplete("$.a", record)
+	p.OnComplete("$.b", record)
+	input := `{"a": "x" ` + strings.Repeat(`"junk" `, 1000) + `, "b": "y"}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("Feed failed: %v", err)
+	}
+	if p.curString.Len() != 0 {
+		t.Errorf("stray strings were buffered: %d bytes", p.curString.Len())
+	}
+	if want := []string{"$.a=x", "$.b=y"}; !slices.Equal(values, want) {
+		t.Errorf("expected %q, got %q", want, values)
+	}
+}
@@ -0,0 +1,70 @@
+package stream
+
+import "encoding/json"
+
+// newContainerValue 为新的容器帧创建待填充的值
+func (p *Parser) newContainerValue(kind frameKind) any {
+	if !p.opts.Materialize {
+		return nil
+	}
+	if kind == frameObject {
+		return map[string]any{}
+	}
+	return []any{}
+}
+
+// attachValue 将一个完成的值挂到栈顶容器上，栈为空时作为文档的根值
+func (p *Parser) attachValue(v any) {
+	if !p.opts.Materialize {
+		return
+	}
+	top := p.stack.top()
+	if top == nil {
+		p.rootValue = v
+		return
+	}
+	switch top.kind {
+	case frameObject:
+		top.val.(map[string]any)[top.key] = v
+	case frameArray:
+		top.val = append(top.val.([]any), v)
+	}
+}
+
+// lastItem 返回数组帧中最后构建的元素（未启用 Materialize 时为 nil）
+func lastItem(f *frame) any {
+	items, _ := f.val.([]any)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[len(items)-1]
+}
+
+// materializedValue 在启用 Materialize 时返回携带完整值的 PartialValue
+func (p *Parser) materializedValue(kind ValueKind, v any) *PartialValue {
+	if !p.opts.Materialize {
+		return nil
+	}
+	return &PartialValue{
+		Kind:     kind,
+		Value:    v,
+		Complete: true,
+	}
+}
+
+// valueKindOf 返回构建出的值对应的类型
+func valueKindOf(v any) ValueKind {
+	switch v.(type) {
+	case map[string]any:
+		return ValueObject
+	case []any:
+		return ValueArray
+	case string:
+		return ValueString
+	case json.Number:
+		return ValueNumber
+	case bool:
+		return ValueBool
+	}
+	return ValueNull
+}
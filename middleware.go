@@ -0,0 +1,56 @@
+package stream
+
+// Middleware 包装 Handler，用于日志、计时、打标签等横切逻辑
+// 中间件可以修改事件、跳过（不调用 next）或在 next 前后执行额外逻辑
+type Middleware func(next Handler) Handler
+
+// handlerChains 按订阅缓存组装好中间件的处理函数
+type handlerChains map[*Subscription]Handler
+
+// Use 注册作用于所有订阅（包括之前已注册的订阅）的中间件，先注册的位于外层
+func (p *Parser) Use(mw ...Middleware) *Parser {
+	p.middleware = append(p.middleware, mw...)
+	p.chains = nil
+	return p
+}
+
+// invoke 通过中间件链将事件交给订阅者，返回处理函数的错误
+func (p *Parser) invoke(sub *Subscription, ev Event) error {
+	if len(p.middleware) == 0 && len(sub.middleware) == 0 && sub.group == nil {
+		return sub.deliver(ev)
+	}
+	h := p.chain(sub)
+	p.handlerErr = nil
+	h(sub.prepare(ev))
+	err := p.handlerErr
+	p.handlerErr = nil
+	return err
+}
+
+// chain 返回订阅组装好中间件的处理函数（按 Parser 缓存，模板共享的订阅互不影响）
+func (p *Parser) chain(sub *Subscription) Handler {
+	if h, ok := p.chains[sub]; ok {
+		return h
+	}
+	h := Handler(func(ev Event) {
+		p.handlerErr = sub.call(ev)
+	})
+	h = wrap(h, sub.middleware)
+	for g := sub.group; g != nil; g = g.parent {
+		h = wrap(h, g.middleware)
+	}
+	h = wrap(h, p.middleware)
+	if p.chains == nil {
+		p.chains = make(handlerChains)
+	}
+	p.chains[sub] = h
+	return h
+}
+
+// wrap 按注册顺序组装中间件，mw[0] 位于最外层
+func wrap(h Handler, mw []Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParser_Use(t *testing.T) {
+	var log []string
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ev Event) {
+				log = append(log, name+">")
+				next(ev)
+				log = append(log, "<"+name)
+			}
+		}
+	}
+
+	p := NewParser()
+	p.OnComplete("$.a", func(Event) { log = append(log, "a") }, WithMiddleware(tag("sub")))
+	// Use 对之前注册的订阅同样生效
+	p.Use(tag("outer"), tag("inner"))
+	p.OnComplete("$.b", func(Event) { log = append(log, "b") })
+
+	if err := p.FeedString(`{"a": 1, "b": 2}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := "outer> inner> sub> a <sub <inner <outer outer> inner> b <inner <outer"
+	if got := strings.Join(log, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestParser_UseFilter(t *testing.T) {
+	onlyComplete := func(next Handler) Handler {
+		return func(ev Event) {
+			if ev.Value != nil && ev.Value.Complete {
+				next(ev)
+			}
+		}
+	}
+
+	var values []string
+	p := NewParser().Use(onlyComplete)
+	p.On("$.text", func(ev Event) {
+		values = append(values, ev.Value.String())
+	}, WithStringMode(StringCumulative))
+
+	p.FeedString(`{"text": "he`)
+	p.FeedString(`llo"}`)
+	if strings.Join(values, ",") != "hello" {
+		t.Errorf("expected only the complete value, got %v", values)
+	}
+}
+
+func TestParser_UseHandlerE(t *testing.T) {
+	calls := 0
+	count := func(next Handler) Handler {
+		return func(ev Event) {
+			calls++
+			next(ev)
+		}
+	}
+
+	p := NewParser().Use(count)
+	p.OnE("$.a", func(Event) error { return ErrStop })
+	if err := p.FeedString(`{"a": 1}`); !errors.Is(err, ErrStop) {
+		t.Fatalf("expected ErrStop through middleware, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected middleware to run once, got %d", calls)
+	}
+}
@@ -0,0 +1,163 @@
+package stream
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// requirement 表示一个对象路径模式及其必需字段
+type requirement struct {
+	expr    string
+	pattern PathPattern
+	fields  []string
+}
+
+// MissingFieldsError 列出对象结束（或输入在对象关闭前结束）时仍未出现的必需字段
+type MissingFieldsError struct {
+	Path   string   // 对象所在路径
+	Fields []string // 缺失的字段，按声明顺序排列
+}
+
+// Error 实现 error 接口
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("stream: object at %q is missing fields: %s", e.Path, strings.Join(e.Fields, ", "))
+}
+
+// Unwrap 使 errors.Is(err, ErrFieldMissing) 成立
+func (e *MissingFieldsError) Unwrap() error {
+	return ErrFieldMissing
+}
+
+// Require 声明匹配 expr 的对象必须包含 fields 中的字段
+// 对象结束时若有字段未出现，会在对象路径上先于 EventObjectEnd 发出 EventMissingFields，
+// 其 Err 为 *MissingFieldsError；Close 时仍未关闭的对象按由内到外的顺序同样检查
+// 同一对象匹配多个声明时字段取并集；需要以错误结束解析时，可用 OnE 订阅该事件并返回 ev.Err
+func (p *Parser) Require(expr string, fields ...string) *Parser {
+	p.required = append(p.required, newRequirement(expr, p.opts.PathSyntax, fields))
+	return p
+}
+
+// RequireStruct 声明匹配 expr 的对象必须包含 v 的必需字段（见 RequiredFields）
+func (p *Parser) RequireStruct(expr string, v any) *Parser {
+	return p.Require(expr, RequiredFields(v)...)
+}
+
+// Require 向模板添加必需字段声明（见 Parser.Require）
+func (t *ParserTemplate) Require(expr string, fields ...string) *ParserTemplate {
+	t.required = append(t.required, newRequirement(expr, t.opts.PathSyntax, fields))
+	return t
+}
+
+// newRequirement 编译路径表达式并创建必需字段声明，表达式非法时 panic
+func newRequirement(expr string, syntax PathSyntax, fields []string) requirement {
+	pat, err := compilePatternSyntax(expr, syntax)
+	if err != nil {
+		panic(err)
+	}
+	return requirement{expr: expr, pattern: pat, fields: slices.Clone(fields)}
+}
+
+// RequiredFields 按 encoding/json 的规则返回结构体 v（或其指针）的必需字段名：
+// 导出字段使用 json tag 中的名称，忽略 `json:"-"` 和带 omitempty 的字段，匿名结构体字段会被展开
+func RequiredFields(v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("stream: RequiredFields of non-struct type %T", v))
+	}
+	return appendRequiredFields(nil, t)
+}
+
+func appendRequiredFields(fields []string, t reflect.Type) []string {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = appendRequiredFields(fields, ft)
+				continue
+			}
+		}
+		if !f.IsExported() || slices.Contains(strings.Split(opts, ","), "omitempty") {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// requiredFields 返回路径为 segments 的对象需要的字段（无声明时返回 nil）
+func (p *Parser) requiredFields(segments []PathSegment) []string {
+	var fields []string
+	for _, r := range p.required {
+		if !match(r.pattern.Segments, segments) {
+			continue
+		}
+		for _, f := range r.fields {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// seeKey 将已出现的 key 从对象帧的缺失字段中移除
+func (f *frame) seeKey(key string) {
+	if i := slices.Index(f.need, key); i >= 0 {
+		f.need = slices.Delete(f.need, i, i+1)
+	}
+}
+
+// emitMissing 若栈中第 i 个对象缺少必需字段，在该对象的路径上发出 EventMissingFields
+func (p *Parser) emitMissing(i int) {
+	f := &p.stack[i]
+	if f.kind != frameObject || len(f.need) == 0 {
+		return
+	}
+	p.updateCachedSegments()
+	p.segmentsDirty = false
+	p.emit(Event{
+		Type:     EventMissingFields,
+		pathOpts: pathOptions{frame: i + 1},
+		Err: &MissingFieldsError{
+			Path:   buildPath(p.frameSegments(i), p.opts.PathSyntax),
+			Fields: slices.Clone(f.need),
+		},
+	})
+}
+
+// checkOpenObjects 在 Close 时按由内到外的顺序检查仍未关闭的对象
+func (p *Parser) checkOpenObjects() {
+	for i := len(p.stack) - 1; i >= 0 && p.err == nil; i-- {
+		p.emitMissing(i)
+	}
+}
+
+// frameSegments 返回栈中第 i 个帧所在容器自身的路径
+func (p *Parser) frameSegments(i int) []PathSegment {
+	n := 0
+	for _, f := range p.stack[:i] {
+		if f.kind == frameArray || f.key != "" {
+			n++
+		}
+	}
+	return p.cachedSegments[:n]
+}
@@ -0,0 +1,169 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// missingLog 记录 EventMissingFields 及其相对 ObjectEnd 的顺序
+type missingLog []string
+
+func (l *missingLog) record(ev Event) {
+	switch ev.Type {
+	case EventMissingFields:
+		var mf *MissingFieldsError
+		if !errors.As(ev.Err, &mf) {
+			*l = append(*l, fmt.Sprintf("bad error %v", ev.Err))
+			return
+		}
+		*l = append(*l, fmt.Sprintf("missing %s %v", mf.Path, mf.Fields))
+	case EventObjectEnd:
+		*l = append(*l, "end "+ev.Path())
+	}
+}
+
+func TestRequire_ObjectEnd(t *testing.T) {
+	p := NewParser()
+	p.Require("$.users[*]", "id", "name", "email")
+	p.Require("$.users[*]", "name", "role")
+
+	var log missingLog
+	p.On("$.users[*]", log.record, WithContainerPath())
+
+	input := `{"users": [{"id": 1, "name": "a", "email": "x", "role": "admin"}, {"id": 2, "nested": {"name": "b"}}]}`
+	for _, r := range input {
+		if err := p.FeedString(string(r)); err != nil {
+			t.Fatalf("Feed failed: %v", err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := []string{
+		"end $.users[0]",
+		"missing $.users[1] [name email role]",
+		"end $.users[1]",
+	}
+	if !reflect.DeepEqual([]string(log), want) {
+		t.Errorf("expected %q, got %q", want, log)
+	}
+}
+
+func TestRequire_OpenObjectsAtClose(t *testing.T) {
+	p := NewParser(WithPathSyntax(PathJSONPointer))
+	p.Require("", "id", "data")
+	p.Require("/data", "title", "body")
+
+	var log missingLog
+	p.On("", log.record)
+	p.On("/data", log.record)
+
+	if err := p.FeedString(`{"id": 7, "data": {"title": "Hel`); err != nil {
+		t.Fatalf("Feed failed: %v", err)
+	}
+	if err := p.Close(); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("expected ErrUnexpectedEOF, got %v", err)
+	}
+
+	want := []string{"missing /data [body]"}
+	if !reflect.DeepEqual([]string(log), want) {
+		t.Errorf("expected %q, got %q", want, log)
+	}
+}
+
+func TestRequire_AbortWithOnE(t *testing.T) {
+	p := NewParser()
+	p.Require("$", "status")
+	p.OnE("$", func(ev Event) error {
+		if ev.Type == EventMissingFields {
+			return ev.Err
+		}
+		return nil
+	}, WithEventTypes(EventMissingFields))
+
+	ended := false
+	p.OnObjectEnd("$", func(Event) { ended = true })
+
+	err := p.FeedString(`{"result": 1}`)
+	if !errors.Is(err, ErrFieldMissing) {
+		t.Fatalf("expected ErrFieldMissing, got %v", err)
+	}
+	var mf *MissingFieldsError
+	if !errors.As(p.Err(), &mf) || mf.Path != "$" || !reflect.DeepEqual(mf.Fields, []string{"status"}) {
+		t.Errorf("unexpected error %#v", p.Err())
+	}
+	if ended {
+		t.Error("ObjectEnd should not be emitted after the parser failed")
+	}
+}
+
+func TestRequire_CheckpointAndFork(t *testing.T) {
+	p := NewParser()
+	p.Require("$", "a", "b")
+	p.FeedString(`{"a": 1, `)
+
+	data, err := p.Checkpoint()
+	if err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+	q := NewParser()
+	q.Require("$", "a", "b")
+	if err := q.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	f := p.Fork(ForkDetachSubscriptions)
+
+	for name, parser := range map[string]*Parser{"restored": q, "forked": f} {
+		var log missingLog
+		parser.On("$", log.record, WithContainerPath())
+		parser.FeedString(`"c": 2}`)
+		want := []string{"missing $ [b]", "end "}
+		if !reflect.DeepEqual([]string(log), want) {
+			t.Errorf("%s: expected %q, got %q", name, want, log)
+		}
+	}
+
+	// 分支不应影响原 Parser
+	var log missingLog
+	p.On("$", log.record, WithContainerPath())
+	p.FeedString(`"b": 2}`)
+	if want := []string{"end "}; !reflect.DeepEqual([]string(log), want) {
+		t.Errorf("original: expected %q, got %q", want, log)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	type Base struct {
+		ID      int    `json:"id"`
+		Created string `json:"created,omitempty"`
+	}
+	type Item struct {
+		Base
+		Name    string `json:"name"`
+		Note    string `json:",omitempty"`
+		Secret  string `json:"-"`
+		Count   int
+		private int
+	}
+	got := RequiredFields(&Item{})
+	want := []string{"id", "name", "Count"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	tpl, err := NewParserTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl.Require("$", got...)
+	p := tpl.NewParser()
+	var log missingLog
+	p.On("$", log.record, WithContainerPath())
+	p.FeedString(`{"name": "x"}`)
+	if want := []string{"missing $ [id Count]", "end "}; !reflect.DeepEqual([]string(log), want) {
+		t.Errorf("expected %q, got %q", want, log)
+	}
+}
@@ -0,0 +1,273 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// numberText 按强制转换策略返回值对应的十进制数字文本
+func (pv *PartialValue) numberText() (string, error) {
+	if pv == nil || pv.Value == nil {
+		return "", pv.typeError(ValueNumber)
+	}
+	if pv.Kind != ValueNumber {
+		s, ok := pv.Value.(string)
+		if !ok || pv.policy == CoercionStrict {
+			return "", pv.typeError(ValueNumber)
+		}
+		s = strings.TrimSpace(s)
+		if !isJSONNumber(s) {
+			return "", pv.typeError(ValueNumber)
+		}
+		return s, nil
+	}
+	switch v := pv.Value.(type) {
+	case json.Number:
+		return string(v), nil
+	case string:
+		return v, nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	}
+	return "", pv.typeError(ValueNumber)
+}
+
+// maxExponent 精确转换时允许的最大指数绝对值，避免 1e999999999 这类输入耗尽内存
+const maxExponent = 10000
+
+// isJSONNumber 判断文本是否符合 JSON 数字语法（不接受 NaN、Inf、十六进制、前导 + 或多余的 0 等）
+func isJSONNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		j := skipDigits(s, i+1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		j := skipDigits(s, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(s)
+}
+
+// skipDigits 返回 s 中从 i 开始的连续数字之后的位置
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// isZeroMantissa 判断数字文本的有效数字是否全为 0（此时指数不影响结果）
+func isZeroMantissa(text string) bool {
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.Trim(text, "-0.") == ""
+}
+
+// parseRat 将数字文本解析为 *big.Rat，文本不符合 JSON 数字语法时返回 ErrNotNumber
+func parseRat(text string) (*big.Rat, error) {
+	if !isJSONNumber(text) {
+		return nil, fmt.Errorf("%w: %q", ErrNotNumber, text)
+	}
+	if isZeroMantissa(text) {
+		return new(big.Rat), nil
+	}
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(strings.TrimPrefix(text[i+1:], "+"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrNotNumber, text)
+		}
+		if exp > maxExponent || exp < -maxExponent {
+			return nil, fmt.Errorf("%w: exponent of %s", ErrNumberOverflow, text)
+		}
+	}
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotNumber, text)
+	}
+	return r, nil
+}
+
+// Number 返回数字的原始文本（json.Number），值不是数字时返回 false
+func (pv *PartialValue) Number() (json.Number, bool) {
+	text, err := pv.numberText()
+	if err != nil {
+		return "", false
+	}
+	if _, err := parseRat(text); err != nil && !errors.Is(err, ErrNumberOverflow) {
+		return "", false
+	}
+	return json.Number(text), true
+}
+
+// Decimal 以 *big.Rat 返回精确的十进制值
+func (pv *PartialValue) Decimal() (*big.Rat, error) {
+	text, err := pv.numberText()
+	if err != nil {
+		return nil, err
+	}
+	return parseRat(text)
+}
+
+// BigInt 以 *big.Int 返回整数值，非整数返回 ErrNotInteger
+func (pv *PartialValue) BigInt() (*big.Int, error) {
+	r, err := pv.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%w: %s", ErrNotInteger, r.FloatString(10))
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// BigFloat 以 *big.Float 返回数值，精度按数字位数自动选择
+func (pv *PartialValue) BigFloat() (*big.Float, error) {
+	text, err := pv.numberText()
+	if err != nil {
+		return nil, err
+	}
+	// 每个十进制位约需 3.33 bit，额外保留 64 bit 余量
+	prec := uint(len(text))*4 + 64
+	f, _, err := big.ParseFloat(text, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrNotNumber, text)
+	}
+	return f, nil
+}
+
+// Int64E 转换为 int64，溢出、非整数或非数字时返回错误
+func (pv *PartialValue) Int64E() (int64, error) {
+	text, err := pv.numberText()
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%w: %s overflows int64", ErrNumberOverflow, text)
+	}
+	n, err := pv.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: %s overflows int64", ErrNumberOverflow, text)
+	}
+	return n.Int64(), nil
+}
+
+// IntE 转换为 int，溢出、非整数或非数字时返回错误
+func (pv *PartialValue) IntE() (int, error) {
+	i, err := pv.Int64E()
+	if err != nil {
+		return 0, err
+	}
+	if i > math.MaxInt || i < math.MinInt {
+		return 0, fmt.Errorf("%w: %d overflows int", ErrNumberOverflow, i)
+	}
+	return int(i), nil
+}
+
+// Uint64E 转换为 uint64，负数、溢出、非整数或非数字时返回错误
+func (pv *PartialValue) Uint64E() (uint64, error) {
+	text, err := pv.numberText()
+	if err != nil {
+		return 0, err
+	}
+	u, err := strconv.ParseUint(text, 10, 64)
+	if err == nil {
+		return u, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%w: %s overflows uint64", ErrNumberOverflow, text)
+	}
+	n, err := pv.BigInt()
+	if err != nil {
+		return 0, err
+	}
+	if n.Sign() < 0 || !n.IsUint64() {
+		return 0, fmt.Errorf("%w: %s overflows uint64", ErrNumberOverflow, text)
+	}
+	return n.Uint64(), nil
+}
+
+// Float64E 转换为 float64，溢出或无法精确往返表示时返回错误
+func (pv *PartialValue) Float64E() (float64, error) {
+	text, err := pv.numberText()
+	if err != nil {
+		return 0, err
+	}
+	if !isJSONNumber(text) {
+		return 0, fmt.Errorf("%w: %q", ErrNotNumber, text)
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%w: %s overflows float64", ErrNumberOverflow, text)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrNotNumber, text)
+	}
+	exact, err := parseRat(text)
+	if err != nil {
+		// 文本已通过语法检查，只可能是指数过小，结果已下溢为 0
+		return f, fmt.Errorf("%w: %s", ErrPrecisionLoss, text)
+	}
+	// 以最短表示回写后与原值比较，判断是否丢失有效位
+	short, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if short == nil || short.Cmp(exact) != 0 {
+		return f, fmt.Errorf("%w: %s", ErrPrecisionLoss, text)
+	}
+	return f, nil
+}
@@ -0,0 +1,199 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPartialValue_Int64E(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *PartialValue
+		want    int64
+		wantErr error
+	}{
+		{
+			name:  "json number",
+			value: &PartialValue{Kind: ValueNumber, Value: json.Number("42")},
+			want:  42,
+		},
+		{
+			name:  "integral exponent",
+			value: &PartialValue{Kind: ValueNumber, Value: json.Number("1.5e3")},
+			want:  1500,
+		},
+		{
+			name:    "fraction",
+			value:   &PartialValue{Kind: ValueNumber, Value: json.Number("42.7")},
+			wantErr: ErrNotInteger,
+		},
+		{
+			name:    "overflow",
+			value:   &PartialValue{Kind: ValueNumber, Value: json.Number("9223372036854775808")},
+			wantErr: ErrNumberOverflow,
+		},
+		{
+			name:    "huge exponent",
+			value:   &PartialValue{Kind: ValueNumber, Value: json.Number("1e999999999")},
+			wantErr: ErrNumberOverflow,
+		},
+		{
+			name:    "uint64 overflow",
+			value:   &PartialValue{Kind: ValueNumber, Value: uint64(1 << 63)},
+			wantErr: ErrNumberOverflow,
+		},
+		{
+			name:    "not a number",
+			value:   &PartialValue{Kind: ValueBool, Value: true},
+			wantErr: ErrTypeMismatch,
+		},
+		{
+			name:    "nil value",
+			value:   nil,
+			wantErr: ErrTypeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value.Int64E()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Int64E() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Int64E() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Int64E() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartialValue_Uint64E(t *testing.T) {
+	pv := &PartialValue{Kind: ValueNumber, Value: json.Number("18446744073709551615")}
+	if got, err := pv.Uint64E(); err != nil || got != 18446744073709551615 {
+		t.Errorf("Uint64E() = %v, %v", got, err)
+	}
+
+	pv = &PartialValue{Kind: ValueNumber, Value: json.Number("-1")}
+	if _, err := pv.Uint64E(); !errors.Is(err, ErrNumberOverflow) {
+		t.Errorf("expected ErrNumberOverflow for negative, got %v", err)
+	}
+}
+
+func TestPartialValue_Float64E(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   json.Number
+		want    float64
+		wantErr error
+	}{
+		{name: "simple", value: "3.14", want: 3.14},
+		{name: "shortest round trip", value: "0.1", want: 0.1},
+		{name: "precision loss", value: "9007199254740993", wantErr: ErrPrecisionLoss},
+		{name: "overflow", value: "1e400", wantErr: ErrNumberOverflow},
+		{name: "underflow", value: "1e-99999", wantErr: ErrPrecisionLoss},
+		{name: "exact zero", value: "0e99999", want: 0},
+		{name: "negative zero", value: "-0.0e-99999", want: 0},
+		{name: "nan", value: "NaN", wantErr: ErrNotNumber},
+		{name: "inf", value: "inf", wantErr: ErrNotNumber},
+		{name: "hex", value: "0x10", wantErr: ErrNotNumber},
+		{name: "leading zero", value: "012", wantErr: ErrNotNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv := &PartialValue{Kind: ValueNumber, Value: tt.value}
+			got, err := pv.Float64E()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Float64E() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Float64E() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Float64E() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartialValue_BigNumbers(t *testing.T) {
+	pv := &PartialValue{Kind: ValueNumber, Value: json.Number("123456789012345678901234567890")}
+
+	n, err := pv.BigInt()
+	if err != nil {
+		t.Fatalf("BigInt() failed: %v", err)
+	}
+	if n.String() != "123456789012345678901234567890" {
+		t.Errorf("BigInt() = %s", n.String())
+	}
+
+	f, err := pv.BigFloat()
+	if err != nil {
+		t.Fatalf("BigFloat() failed: %v", err)
+	}
+	if f.Text('f', 0) != "123456789012345678901234567890" {
+		t.Errorf("BigFloat() = %s", f.Text('f', 0))
+	}
+
+	d, err := (&PartialValue{Kind: ValueNumber, Value: json.Number("0.125")}).Decimal()
+	if err != nil {
+		t.Fatalf("Decimal() failed: %v", err)
+	}
+	if d.RatString() != "1/8" {
+		t.Errorf("Decimal() = %s, want 1/8", d.RatString())
+	}
+}
+
+// TestPartialValue_NumberGrammar 测试只接受符合 JSON 数字语法的文本
+func TestPartialValue_NumberGrammar(t *testing.T) {
+	for _, text := range []string{"0", "-0", "12", "-1.5", "1e5", "1E+5", "2.5e-3", "0e99999"} {
+		if !isJSONNumber(text) {
+			t.Errorf("isJSONNumber(%q) = false", text)
+		}
+	}
+	for _, text := range []string{"", "-", "+1", ".5", "1.", "01", "1e", "1e+", "0x10", "1_000", "NaN", "Inf", " 1"} {
+		if isJSONNumber(text) {
+			t.Errorf("isJSONNumber(%q) = true", text)
+		}
+		pv := &PartialValue{Kind: ValueNumber, Value: json.Number(text)}
+		if _, ok := pv.Number(); ok {
+			t.Errorf("Number(%q) accepted invalid text", text)
+		}
+		if _, err := pv.Decimal(); !errors.Is(err, ErrNotNumber) {
+			t.Errorf("Decimal(%q) error = %v, want ErrNotNumber", text, err)
+		}
+	}
+
+	d, err := (&PartialValue{Kind: ValueNumber, Value: json.Number("0e99999")}).Decimal()
+	if err != nil || d.Sign() != 0 {
+		t.Errorf("Decimal(0e99999) = %v, %v, want exact zero", d, err)
+	}
+}
+
+// TestParser_NumberValue 测试 Parser 以 json.Number 形式输出数字
+func TestParser_NumberValue(t *testing.T) {
+	var got any
+	p := NewParser()
+	p.On("$.n", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			got = ev.Value.Value
+		}
+	})
+	if err := p.FeedString(`{"n": 12345678901234567890}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	num, ok := got.(json.Number)
+	if !ok || num != "12345678901234567890" {
+		t.Fatalf("expected json.Number, got %T(%v)", got, got)
+	}
+}
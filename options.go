@@ -0,0 +1,201 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Options Parser 的配置选项
+type Options struct {
+	Observer       ParserObserver // 观察者（nil 表示不观察）
+	Coercion       CoercionPolicy // 值访问器的强制转换策略
+	Recovery       bool           // 是否启用错误恢复
+	Limits         Limits         // 资源限制
+	PathSyntax     PathSyntax     // 订阅表达式与 Event.Path 使用的路径语法
+	Flush          FlushPolicy    // 字符串增量事件的发出策略
+	Materialize    bool           // 是否为对象/数组构建完整的值
+	PartialNumbers bool           // 是否为未结束的数字发出增量事件
+	KeyEvents      bool           // 是否发出 EventKey / EventFieldStart
+	PartialKeys    bool           // 是否为未结束的 key 发出增量 EventKey
+	Panics         PanicPolicy    // Handler panic 时的处理方式
+	Strict         bool           // 是否严格检查语法（启用错误恢复时总是检查）
+	RootStreamEnd  bool           // 是否在顶层对象/数组结束时立即发出 EventStreamEnd（旧版行为）
+}
+
+// Option 是 NewParser 的函数式选项
+type Option func(*Options)
+
+// WithObserver 设置观察者
+func WithObserver(ob ParserObserver) Option {
+	return func(o *Options) {
+		o.Observer = ob
+	}
+}
+
+// WithDebug 使用调试配置启用调试观察者
+func WithDebug(config *DebugConfig) Option {
+	return func(o *Options) {
+		o.Observer = NewDebugObserver(config)
+	}
+}
+
+// WithCoercion 设置值访问器的强制转换策略
+func WithCoercion(c CoercionPolicy) Option {
+	return func(o *Options) {
+		o.Coercion = c
+	}
+}
+
+// WithRecovery 启用错误恢复
+func WithRecovery() Option {
+	return func(o *Options) {
+		o.Recovery = true
+	}
+}
+
+// WithLimits 设置资源限制
+func WithLimits(l Limits) Option {
+	return func(o *Options) {
+		o.Limits = l
+	}
+}
+
+// WithPathSyntax 设置路径语法
+func WithPathSyntax(s PathSyntax) Option {
+	return func(o *Options) {
+		o.PathSyntax = s
+	}
+}
+
+// WithFlushPolicy 设置字符串增量事件的发出策略
+func WithFlushPolicy(fp FlushPolicy) Option {
+	return func(o *Options) {
+		o.Flush = fp
+	}
+}
+
+// WithMaterialize 为对象/数组构建完整的值，
+// 附加在 ObjectEnd/ArrayEnd/ArrayItem/DocumentEnd 事件的 Value 上
+func WithMaterialize() Option {
+	return func(o *Options) {
+		o.Materialize = true
+	}
+}
+
+// WithPartialNumbers 为未结束的数字发出增量事件
+func WithPartialNumbers() Option {
+	return func(o *Options) {
+		o.PartialNumbers = true
+	}
+}
+
+// WithKeyEvents 启用 EventKey / EventFieldStart
+func WithKeyEvents() Option {
+	return func(o *Options) {
+		o.KeyEvents = true
+	}
+}
+
+// WithPartialKeys 启用字段事件，并为未结束的 key 发出增量 EventKey
+func WithPartialKeys() Option {
+	return func(o *Options) {
+		o.KeyEvents = true
+		o.PartialKeys = true
+	}
+}
+
+// WithStrict 严格检查语法：缺失的逗号、冒号等不再被容忍，而是作为 ErrUnexpectedToken 报告
+func WithStrict() Option {
+	return func(o *Options) {
+		o.Strict = true
+	}
+}
+
+// WithRootStreamEnd 恢复多文档支持之前的行为：顶层对象/数组结束时立即发出 EventStreamEnd，
+// 不必调用 Close；之后 Close 只在输入不完整时再发出携带 Err 的 EventStreamEnd
+// 第一个 EventStreamEnd 会结束事件通道和等待中的 Future，因此只适用于单文档输入
+func WithRootStreamEnd() Option {
+	return func(o *Options) {
+		o.RootStreamEnd = true
+	}
+}
+
+// WithPanicPolicy 设置 Handler panic 时的处理方式
+func WithPanicPolicy(pp PanicPolicy) Option {
+	return func(o *Options) {
+		o.Panics = pp
+	}
+}
+
+// validate 检查选项是否合法
+func (o *Options) validate() error {
+	if o.Coercion < CoercionLenient || o.Coercion > CoercionStrict {
+		return fmt.Errorf("%w: unknown coercion policy %s", ErrInvalidOption, o.Coercion)
+	}
+	if o.PathSyntax < PathJSONPath || o.PathSyntax > PathJSONPointer {
+		return fmt.Errorf("%w: unknown path syntax %s", ErrInvalidOption, o.PathSyntax)
+	}
+	if o.Panics < PanicPropagate || o.Panics > PanicStop {
+		return fmt.Errorf("%w: unknown panic policy %s", ErrInvalidOption, o.Panics)
+	}
+	if err := o.Limits.validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOption, err)
+	}
+	if err := o.Flush.validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOption, err)
+	}
+	if o.PartialKeys && !o.KeyEvents {
+		return fmt.Errorf("%w: partial keys require key events", ErrInvalidOption)
+	}
+	return nil
+}
+
+// String 返回便于日志输出的选项摘要
+func (o Options) String() string {
+	parts := []string{
+		"coercion=" + o.Coercion.String(),
+		"path=" + o.PathSyntax.String(),
+		"flush=" + o.Flush.Mode.String(),
+	}
+	switch o.Flush.Mode {
+	case FlushEveryRunes:
+		parts = append(parts, fmt.Sprintf("flush_runes=%d", o.Flush.Runes))
+	case FlushOnInterval:
+		parts = append(parts, "flush_interval="+o.Flush.Interval.String())
+	}
+	if o.Panics != PanicPropagate {
+		parts = append(parts, "panics="+o.Panics.String())
+	}
+	if o.Limits != (Limits{}) {
+		parts = append(parts, fmt.Sprintf("limits=%+v", o.Limits))
+	}
+	for _, flag := range []struct {
+		name string
+		on   bool
+	}{
+		{"recovery", o.Recovery},
+		{"strict", o.Strict},
+		{"materialize", o.Materialize},
+		{"partial_numbers", o.PartialNumbers},
+		{"key_events", o.KeyEvents},
+		{"partial_keys", o.PartialKeys},
+		{"root_stream_end", o.RootStreamEnd},
+	} {
+		if flag.on {
+			parts = append(parts, flag.name)
+		}
+	}
+	if o.Observer != nil {
+		parts = append(parts, fmt.Sprintf("observer=%T", o.Observer))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Options 返回 Parser 当前生效的选项
+func (p *Parser) Options() Options {
+	o := p.opts
+	if p.ob != defaultObserver {
+		o.Observer = p.ob
+	}
+	return o
+}
@@ -0,0 +1,126 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewParserE_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"negative limit", WithLimits(Limits{MaxDepth: -1})},
+		{"bad flush policy", WithFlushPolicy(FlushEvery(0))},
+		{"unknown coercion", WithCoercion(CoercionPolicy(42))},
+		{"unknown path syntax", WithPathSyntax(PathSyntax(42))},
+		{"unknown panic policy", WithPanicPolicy(PanicPolicy(42))},
+		{"partial keys without key events", func(o *Options) { o.PartialKeys = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParserE(tt.opt)
+			if !errors.Is(err, ErrInvalidOption) {
+				t.Fatalf("expected ErrInvalidOption, got %v", err)
+			}
+			if p != nil {
+				t.Error("expected nil parser on invalid options")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewParser to panic on invalid options")
+		}
+	}()
+	NewParser(WithLimits(Limits{MaxBytes: -1}))
+}
+
+func TestNewParser_Options(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewParser(
+		WithDebug(&DebugConfig{Writer: &buf, Level: DebugLevelError}),
+		WithCoercion(CoercionStrict),
+		WithRecovery(),
+		WithLimits(Limits{MaxDepth: 8}),
+		WithFlushPolicy(FlushEvery(16)),
+		WithPartialKeys(),
+	)
+
+	o := p.Options()
+	if o.Coercion != CoercionStrict || !o.Recovery || o.Limits.MaxDepth != 8 ||
+		o.Flush.Runes != 16 || !o.KeyEvents || !o.PartialKeys || o.Observer == nil {
+		t.Errorf("unexpected options: %+v", o)
+	}
+	s := o.String()
+	for _, want := range []string{"coercion=Strict", "flush=EveryRunes", "flush_runes=16", "recovery", "partial_keys"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Options().String() = %q, missing %q", s, want)
+		}
+	}
+
+	// 选项与原有的配置方法保持一致
+	p.DisableDebug()
+	if p.Options().Observer != nil {
+		t.Error("expected no observer after DisableDebug")
+	}
+	if err := p.FeedString(`{"a": [[[[[[[[[1]]]]]]]]]}`); !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("expected ErrMaxDepthExceeded from limits option, got %v", err)
+	}
+}
+
+func TestNewParser_PathSyntax(t *testing.T) {
+	var paths []string
+	p := NewParser(WithPathSyntax(PathJSONPointer))
+	p.On("/items/*/a~1b", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			paths = append(paths, ev.Path())
+		}
+	})
+	if err := p.FeedString(`{"items": [{"a/b": 1}, {"a/b": 2}]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []string{"/items/0/a~1b", "/items/1/a~1b"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+}
+
+func TestNewParser_Materialize(t *testing.T) {
+	var items []any
+	var doc any
+	p := NewParser(WithMaterialize())
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventArrayItem {
+			items = append(items, ev.Value.Value)
+		}
+	})
+	p.On("$", func(ev Event) {
+		if ev.Type == EventDocumentEnd {
+			doc = ev.Value.Value
+		}
+	})
+	if err := p.FeedString(`{"items": [{"id": 1, "tags": ["x"]}, "s", null], "ok": true}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	wantItems := []any{
+		map[string]any{"id": json.Number("1"), "tags": []any{"x"}},
+		"s",
+		nil,
+	}
+	if !reflect.DeepEqual(items, wantItems) {
+		t.Errorf("items = %#v, want %#v", items, wantItems)
+	}
+	wantDoc := map[string]any{"items": wantItems, "ok": true}
+	if !reflect.DeepEqual(doc, wantDoc) {
+		t.Errorf("document = %#v, want %#v", doc, wantDoc)
+	}
+}
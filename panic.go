@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicPolicy 决定订阅者 Handler panic 时的处理方式
+type PanicPolicy int
+
+const (
+	// PanicPropagate 不恢复 panic（默认），panic 会穿过 Feed 向上传播
+	PanicPropagate PanicPolicy = iota
+	// PanicContinue 恢复 panic 并通过 ParserObserver.OnError 报告，继续调用其余订阅者
+	PanicContinue
+	// PanicStop 恢复 panic 并停止解析，*HandlerPanicError 由 Feed 和 Parser.Err 返回
+	PanicStop
+)
+
+// String 返回 panic 策略的字符串表示
+func (pp PanicPolicy) String() string {
+	switch pp {
+	case PanicPropagate:
+		return "Propagate"
+	case PanicContinue:
+		return "Continue"
+	case PanicStop:
+		return "Stop"
+	default:
+		return fmt.Sprintf("PanicPolicy(%d)", pp)
+	}
+}
+
+// HandlerPanicError 表示订阅者 Handler 发生的 panic
+type HandlerPanicError struct {
+	Pattern string    // 订阅的路径表达式
+	Path    string    // 事件路径
+	Event   EventType // 事件类型
+	Value   any       // recover 得到的值
+	Stack   []byte    // panic 时的调用栈
+}
+
+// Error 实现 error 接口
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler for %q panicked on %s at %q: %v", e.Pattern, e.Event, e.Path, e.Value)
+}
+
+// Unwrap 在 panic 的值本身是 error 时返回它
+func (e *HandlerPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// SetPanicPolicy 设置 Handler panic 时的处理方式
+func (p *Parser) SetPanicPolicy(pp PanicPolicy) {
+	p.opts.Panics = pp
+}
+
+// PanicPolicy 返回当前的 panic 处理方式
+func (p *Parser) PanicPolicy() PanicPolicy {
+	return p.opts.Panics
+}
+
+// deliver 将事件交给订阅者，按 panic 策略隔离 Handler 的 panic
+func (p *Parser) deliver(sub *Subscription, ev Event) (err error) {
+	if p.opts.Panics == PanicPropagate {
+		return p.invoke(sub, ev)
+	}
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		perr := &HandlerPanicError{
+			Pattern: sub.expr,
+			Path:    ev.Path(),
+			Event:   ev.Type,
+			Value:   r,
+			Stack:   debug.Stack(),
+		}
+		if p.opts.Panics == PanicStop {
+			// 由 emit 停止解析并报告
+			err = perr
+			return
+		}
+		p.ob.OnError(perr, func() map[string]any {
+			return map[string]any{
+				"action":  "handler_panic",
+				"pattern": sub.expr,
+				"path":    perr.Path,
+			}
+		})
+	}()
+	return p.invoke(sub, ev)
+}
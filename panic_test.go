@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"errors"
+	"testing"
+)
+
+// errorRecorder 记录 OnError 收到的错误
+type errorRecorder struct {
+	noopObserver
+	errs []error
+}
+
+func (r *errorRecorder) OnError(err error, _ DebugContext) {
+	r.errs = append(r.errs, err)
+}
+
+func TestParser_PanicContinue(t *testing.T) {
+	rec := &errorRecorder{}
+	p := NewParser(WithObserver(rec), WithPanicPolicy(PanicContinue))
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventFieldValue && ev.Value.Int() == 2 {
+			panic("boom")
+		}
+	})
+	var ids []int
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventFieldValue {
+			ids = append(ids, ev.Value.Int())
+		}
+	})
+
+	if err := p.FeedString(`{"items": [1, 2, 3]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("expected remaining subscribers to receive all items, got %v", ids)
+	}
+	if len(rec.errs) != 1 {
+		t.Fatalf("expected one reported panic, got %v", rec.errs)
+	}
+	var perr *HandlerPanicError
+	if !errors.As(rec.errs[0], &perr) {
+		t.Fatalf("expected *HandlerPanicError, got %T", rec.errs[0])
+	}
+	if perr.Pattern != "$.items[*]" || perr.Path != "$.items[1]" || perr.Value != "boom" || len(perr.Stack) == 0 {
+		t.Errorf("unexpected panic error: %+v", perr)
+	}
+}
+
+func TestParser_PanicStop(t *testing.T) {
+	errBoom := errors.New("boom")
+	p := NewParser(WithPanicPolicy(PanicStop))
+	p.On("$.a", func(Event) { panic(errBoom) })
+	called := false
+	p.On("$.b", func(Event) { called = true })
+
+	err := p.FeedString(`{"a": 1, "b": 2}`)
+	var perr *HandlerPanicError
+	if !errors.As(err, &perr) || !errors.Is(err, errBoom) {
+		t.Fatalf("expected *HandlerPanicError wrapping the panic value, got %v", err)
+	}
+	if called || !errors.Is(p.Err(), errBoom) {
+		t.Errorf("expected parsing to stop, called=%v err=%v", called, p.Err())
+	}
+}
+
+func TestParser_PanicPropagate(t *testing.T) {
+	p := NewParser()
+	p.On("$.a", func(Event) { panic("boom") })
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic to propagate by default")
+		}
+	}()
+	p.FeedString(`{"a": 1}`)
+}
+
+// TestParser_PanicPropagateResubscribe 测试 panic 穿过 Feed 后，调用方恢复并继续使用 Parser 时新订阅立即生效
+func TestParser_PanicPropagateResubscribe(t *testing.T) {
+	p := NewParser()
+	p.On("$.a", func(Event) { panic("boom") })
+	func() {
+		defer func() { recover() }()
+		p.FeedString(`{"a": "x"}`)
+	}()
+	if p.dispatching {
+		t.Fatal("dispatching flag left set after panic")
+	}
+
+	p.Reset()
+	var got []string
+	p.OnComplete("$.b", func(ev Event) { got = append(got, ev.Value.String()) })
+	p.FeedString(`{"b": 2}`)
+	if len(got) != 1 || got[0] != "2" {
+		t.Errorf("expected subscription added after panic to receive 2, got %q", got)
+	}
+}
@@ -1,7 +1,15 @@
 package stream
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // valueKind 表示当前正在构建的值类型
@@ -39,28 +47,88 @@ type Parser struct {
 	curValueKind   valueKind       // 当前值的类型
 	curString      strings.Builder // string 临时拼装
 	curNumber      strings.Builder // number 临时拼装
+	numberFlushed  int             // curNumber 中已作为增量事件发出的字节数
+	keyFlushed     int             // curString 中已作为增量 key 事件发出的字节数
 	chunkBuffer    strings.Builder // 字符串 chunk 缓冲区
+	chunkRunes     int             // chunkBuffer 中的字符数
+	lastFlush      time.Time       // 上次发出字符串增量事件的时间
+	now            clock           // 时钟（用于按时间间隔发出，测试中可替换）
 	subs           []*Subscription // 订阅列表
 	tokenizer      *Tokenizer      // tokenizer 实例
 	err            error           // 解析过程中的错误
 	ob             ParserObserver  // 观察者 用于 Debug 等功能
+	opts           Options         // 解析选项
 	cachedSegments []PathSegment   // 缓存的路径段数组
 	segmentsDirty  bool            // 标记 segments 是否需要重新计算
 	lastValueKind  ValueKind       // 当前值的类型
+	bytes          int             // 已输入的字节数
+	skipping       bool            // 是否正在跳过损坏的元素
+	skipDepth      int             // 跳过过程中未闭合的括号层数
+	curToken       TokenType       // 当前正在处理的 token 类型
+	docIndex       int             // 当前文档序号
+	inDocument     bool            // 是否处于某个顶层文档内部
+	closed         bool            // 是否已调用 Close
+	rootValue      any             // 当前文档构建出的完整值（需启用 Materialize）
+	sinks          []*eventSink    // 通过 Events 创建的事件通道
+	tap            Handler         // 接收所有事件（用于 Decoder 的拉取式 API）
+	middleware     []Middleware    // 作用于所有订阅的中间件
+	chains         handlerChains   // 已组装中间件的处理函数
+	handlerErr     error           // 中间件链中 HandlerE 返回的错误
+	stopped        bool            // 当前事件是否已停止传播
+	dispatching    bool            // 是否正在向订阅者投递事件
+	pendingSubs    []*Subscription // 投递期间新增的订阅，投递结束后加入
+	scoped         int             // 作用域订阅的数量
+	containers     uint64          // 已开始的容器数量，用于标识容器实例
+	futures        []*Future       // 等待中的 Future（只在解析 goroutine 中访问）
+	futureMu       sync.Mutex      // 保护 newFutures
+	newFutures     []*Future       // 其他 goroutine 新注册、尚未生效的 Future
+	hasNewFutures  atomic.Bool     // 是否有新注册的 Future
+	futuresDone    bool            // 解析已结束，新注册的 Future 立即失败（受 futureMu 保护）
+	futureErr      error           // 解析结束的错误，nil 表示正常结束（受 futureMu 保护）
+	required       []requirement   // 通过 Require 声明的必需字段
+	unions         unionStates     // OnUnion 路由器在该 Parser 上的状态
 }
 
-// NewParser 创建一个新的 Parser
-func NewParser() *Parser {
+// NewParser 使用选项创建一个新的 Parser，选项非法时 panic
+func NewParser(opts ...Option) *Parser {
+	p, err := NewParserE(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// NewParserE 使用选项创建一个新的 Parser，选项非法时返回错误
+func NewParserE(opts ...Option) (*Parser, error) {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return newParser(o, make([]*Subscription, 0, 8)), nil
+}
+
+// newParser 使用已校验的选项和订阅列表创建 Parser
+func newParser(o Options, subs []*Subscription) *Parser {
 	p := &Parser{
 		state: pIdle,
-		subs:  make([]*Subscription, 0, 8),
+		subs:  subs,
 		stack: make(stack, 0, 32),
 		ob:    defaultObserver,
+		opts:  o,
+		now:   time.Now,
+	}
+	if o.Observer != nil {
+		p.ob = o.Observer
 	}
+	p.opts.Observer = nil
 
 	p.tokenizer = NewTokenizer(func(tok Token) {
 		p.OnToken(tok)
 	})
+	p.tokenizer.SetLimits(o.Limits)
 
 	return p
 }
@@ -75,32 +143,111 @@ func (p *Parser) DisableDebug() {
 	p.ob = defaultObserver
 }
 
-// On 订阅指定路径的事件
-func (p *Parser) On(expr string, h Handler) *Parser {
-	pat, err := CompilePattern(expr)
-	if err != nil {
-		panic(err)
-	}
-	p.subs = append(p.subs, &Subscription{
-		Pattern: pat,
-		Handler: h,
-	})
+// EnableRecovery 启用错误恢复：遇到语法错误时发出 EventError，
+// 丢弃当前损坏的元素，并在外层数组的下一个元素（或下一个顶层文档）处继续解析
+func (p *Parser) EnableRecovery() {
+	p.opts.Recovery = true
+}
+
+// EnableStrict 严格检查语法（见 WithStrict）
+func (p *Parser) EnableStrict() {
+	p.opts.Strict = true
+}
+
+// DisableStrict 恢复宽松解析，仍启用错误恢复时语法检查继续生效
+func (p *Parser) DisableStrict() {
+	p.opts.Strict = false
+}
+
+// DisableRecovery 禁用错误恢复，第一个错误即终止解析
+func (p *Parser) DisableRecovery() {
+	p.opts.Recovery = false
+}
+
+// EnablePartialNumbers 在每次 Feed 结束时为尚未结束的数字发出 Append 事件
+// （Value 为新增的字符，Complete 为 false），最终值仍以一个 Complete 事件发出
+func (p *Parser) EnablePartialNumbers() {
+	p.opts.PartialNumbers = true
+}
+
+// DisablePartialNumbers 禁用数字增量事件
+func (p *Parser) DisablePartialNumbers() {
+	p.opts.PartialNumbers = false
+}
+
+// EnableKeyEvents 启用字段事件：key 确定后向该字段路径发出 EventKey，
+// 值开始时发出 EventFieldStart（早于任何值内容）
+func (p *Parser) EnableKeyEvents() {
+	p.opts.KeyEvents = true
+}
+
+// EnablePartialKeys 启用字段事件，并在每次 Feed 结束时为未结束的 key 发出增量 EventKey
+// 由于 key 尚未确定，增量事件发往所在对象的路径
+func (p *Parser) EnablePartialKeys() {
+	p.opts.KeyEvents = true
+	p.opts.PartialKeys = true
+}
+
+// DisableKeyEvents 禁用字段事件
+func (p *Parser) DisableKeyEvents() {
+	p.opts.KeyEvents = false
+	p.opts.PartialKeys = false
+}
+
+// On 订阅指定路径的事件，可通过 opts 调整订阅行为
+// 同一事件按优先级从高到低投递，优先级相同时按注册顺序投递（见 WithPriority）
+func (p *Parser) On(expr string, h Handler, opts ...SubscribeOption) *Parser {
+	p.subscribe(newSubscription(expr, p.opts.PathSyntax, h, opts))
+	return p
+}
+
+// OnE 订阅指定路径的事件，h 返回错误时停止解析（见 HandlerE）
+func (p *Parser) OnE(expr string, h HandlerE, opts ...SubscribeOption) *Parser {
+	sub := newSubscription(expr, p.opts.PathSyntax, nil, opts)
+	sub.handlerE = h
+	p.subscribe(sub)
 	return p
 }
 
 func (p *Parser) emit(ev Event) {
+	if p.err != nil {
+		return
+	}
 	if p.segmentsDirty || len(p.cachedSegments) != len(p.stack) {
 		p.updateCachedSegments()
 		p.segmentsDirty = false
 	}
 
+	// segments 为事件自身的路径；routing 为普通订阅匹配的路径，
+	// ObjectEnd/ArrayStart/ArrayEnd 按事件发生时的完整路径匹配（见 WithContainerPath）
 	segments := p.cachedSegments
+	routing := segments
+	if ev.pathOpts.frame > 0 {
+		segments = p.frameSegments(ev.pathOpts.frame - 1)
+		routing = segments
+	} else if ev.pathOpts.excludeTop || ev.pathOpts.excludeTopIndex {
+		segments = p.containerSegments()
+		if ev.Type == EventKey {
+			routing = segments
+		}
+	}
+	ev.pathOpts = pathOptions{}
+	ev.syntax = p.opts.PathSyntax
+	ev.Document = p.docIndex
 
 	if len(segments) == 0 {
 		ev.pathSegments = nil
 	} else {
 		ev.pathSegments = append([]PathSegment(nil), segments...)
 	}
+	if ev.Value != nil {
+		ev.Value.policy = p.opts.Coercion
+		ev.Value.pathSyntax = p.opts.PathSyntax
+		ev.Value.pathSegments = ev.pathSegments
+		if ev.Value.pathSegments == nil {
+			ev.Value.pathSegments = []PathSegment{}
+		}
+	}
 
 	p.ob.OnEvent(ev, func() map[string]any {
 		return map[string]any{
@@ -109,16 +256,98 @@ func (p *Parser) emit(ev Event) {
 		}
 	})
 
+	if !p.dispatch(ev, segments, routing) {
+		return
+	}
+	p.resolveFutures(ev, segments)
+	if ev.Type == EventStreamEnd {
+		p.rejectFutures(ev.Err)
+	}
+	if len(p.sinks) > 0 {
+		p.dispatchSinks(ev, segments)
+	}
+	if p.tap != nil {
+		p.tap(ev)
+	}
+}
+
+// dispatch 按优先级将事件投递给匹配的订阅，Handler 出错时停止解析并返回 false
+// 启用 WithContainerPath 的订阅按事件自身的路径 segments 匹配，其他订阅按 routing 匹配
+func (p *Parser) dispatch(ev Event, segments, routing []PathSegment) bool {
+	p.stopped = false
+	p.dispatching = true
+	defer p.endDispatch()
+	ev.stop = &p.stopped
+	ev.parser = p
+	ok := true
 	for _, sub := range p.subs {
-		if match(sub.Pattern.Segments, segments) {
-			sub.Handler(ev)
+		if p.stopped {
+			break
+		}
+		path := routing
+		if sub.containerPath {
+			path = segments
+		}
+		if !sub.matches(path) || (sub.filter != nil && !sub.filter(ev)) {
+			continue
+		}
+		if err := p.deliver(sub, ev); err != nil {
+			if errors.Is(err, ErrStopPropagation) {
+				break
+			}
+			ok = false
+			if errors.Is(err, ErrStop) {
+				p.halt(err)
+				break
+			}
+			p.abort(err, func() map[string]any {
+				return map[string]any{
+					"action": "handler",
+					"event":  ev.Type,
+					"path":   ev.Path(),
+				}
+			})
+			break
 		}
 	}
+	return ok
 }
 
 // OnToken 处理一个 token
 func (p *Parser) OnToken(tok Token) {
 	p.ob.OnToken(tok, p.state, p.tokenizer.state)
+	p.curToken = tok.Type
+	if p.skipping {
+		p.skipToken(tok.Type)
+		return
+	}
+	if err := p.checkGrammar(tok.Type); err != nil {
+		p.fail(err, func() map[string]any {
+			return map[string]any{
+				"action": "check_token",
+				"token":  tok.Type,
+				"state":  p.state.String(),
+			}
+		})
+		return
+	}
+	if err := p.checkLimits(tok.Type); err != nil {
+		p.abort(err, func() map[string]any {
+			return map[string]any{
+				"action":      "check_limits",
+				"token":       tok.Type,
+				"stack_depth": len(p.stack),
+			}
+		})
+		return
+	}
+	if p.state == pIdle && !p.inDocument && isValueStart(tok.Type) {
+		p.beginDocument()
+	}
+	if p.opts.KeyEvents && p.state == pObjExpectValue &&
+		p.curValueKind == valNone && isValueStart(tok.Type) {
+		p.emitFieldStart(tok.Type)
+	}
 	switch tok.Type {
 	case TokenLBrace:
 		p.onObjectStart()
@@ -149,8 +378,13 @@ func (p *Parser) OnToken(tok Token) {
 
 func (p *Parser) onObjectStart() {
 	oldState := p.state
-	p.stack = append(p.stack, frame{kind: frameObject})
+	p.containers++
+	p.stack = append(p.stack, frame{kind: frameObject, id: p.containers, val: p.newContainerValue(frameObject)})
 	p.segmentsDirty = true
+	if len(p.required) > 0 {
+		p.updateCachedSegments()
+		p.stack.top().need = p.requiredFields(p.cachedSegments)
+	}
 	p.state = pObjExpectKey
 	p.ob.OnStateChange(oldState, p.state, func() map[string]any {
 		return map[string]any{
@@ -171,8 +405,7 @@ func (p *Parser) onObjectStart() {
 func (p *Parser) onObjectEnd() {
 	top := p.stack.top()
 	if top == nil {
-		p.err = ErrMismatchedBrace
-		p.ob.OnError(p.err, func() map[string]any {
+		p.fail(ErrMismatchedBrace, func() map[string]any {
 			return map[string]any{
 				"action":      "object_end",
 				"stack_empty": true,
@@ -181,8 +414,7 @@ func (p *Parser) onObjectEnd() {
 		return
 	}
 	if top.kind != frameObject {
-		p.err = ErrMismatchedBrace
-		p.ob.OnError(p.err, func() map[string]any {
+		p.fail(ErrMismatchedBrace, func() map[string]any {
 			return map[string]any{
 				"action":   "object_end",
 				"expected": "frameObject",
@@ -192,16 +424,22 @@ func (p *Parser) onObjectEnd() {
 		return
 	}
 
+	p.emitMissing(len(p.stack) - 1)
+	val := top.val
 	p.emit(Event{
 		Type:     EventObjectEnd,
 		pathOpts: pathOptions{excludeTop: true},
+		Value:    p.materializedValue(ValueObject, val),
 	})
 
+	p.dropScopes(len(p.stack))
+
 	parent := p.stack.parent()
 
 	oldState := p.state
 	p.stack = p.stack[:len(p.stack)-1]
 	p.segmentsDirty = true
+	p.attachValue(val)
 	topAfterPop := p.stack.top()
 	if topAfterPop == nil {
 		p.state = pIdle
@@ -211,7 +449,7 @@ func (p *Parser) onObjectEnd() {
 				"result": "idle",
 			}
 		})
-		p.emitStreamEnd()
+		p.endRootContainer()
 		return
 	}
 
@@ -221,6 +459,7 @@ func (p *Parser) onObjectEnd() {
 			pathOpts: pathOptions{},
 			Value: &PartialValue{
 				Kind:     ValueObject,
+				Value:    val,
 				Complete: true,
 			},
 		})
@@ -253,7 +492,8 @@ func (p *Parser) onObjectEnd() {
 
 func (p *Parser) onArrayStart() {
 	oldState := p.state
-	p.stack = append(p.stack, frame{kind: frameArray})
+	p.containers++
+	p.stack = append(p.stack, frame{kind: frameArray, id: p.containers, val: p.newContainerValue(frameArray)})
 	p.segmentsDirty = true
 	p.state = pArrExpectValue
 	p.ob.OnStateChange(oldState, p.state, func() map[string]any {
@@ -275,8 +515,7 @@ func (p *Parser) onArrayStart() {
 func (p *Parser) onArrayEnd() {
 	top := p.stack.top()
 	if top == nil {
-		p.err = ErrMismatchedBracket
-		p.ob.OnError(p.err, func() map[string]any {
+		p.fail(ErrMismatchedBracket, func() map[string]any {
 			return map[string]any{
 				"action":      "array_end",
 				"stack_empty": true,
@@ -285,8 +524,7 @@ func (p *Parser) onArrayEnd() {
 		return
 	}
 	if top.kind != frameArray {
-		p.err = ErrMismatchedBracket
-		p.ob.OnError(p.err, func() map[string]any {
+		p.fail(ErrMismatchedBracket, func() map[string]any {
 			return map[string]any{
 				"action":   "array_end",
 				"expected": "frameArray",
@@ -296,16 +534,21 @@ func (p *Parser) onArrayEnd() {
 		return
 	}
 
+	val := top.val
 	p.emit(Event{
 		Type:     EventArrayEnd,
 		pathOpts: pathOptions{excludeTop: true},
+		Value:    p.materializedValue(ValueArray, val),
 	})
 
+	p.dropScopes(len(p.stack))
+
 	parent := p.stack.parent()
 
 	oldState := p.state
 	p.stack = p.stack[:len(p.stack)-1]
 	p.segmentsDirty = true
+	p.attachValue(val)
 	topAfterPop := p.stack.top()
 	if topAfterPop == nil {
 		p.state = pIdle
@@ -315,7 +558,7 @@ func (p *Parser) onArrayEnd() {
 				"result": "idle",
 			}
 		})
-		p.emitStreamEnd()
+		p.endRootContainer()
 		return
 	}
 
@@ -325,6 +568,7 @@ func (p *Parser) onArrayEnd() {
 			pathOpts: pathOptions{},
 			Value: &PartialValue{
 				Kind:     ValueArray,
+				Value:    val,
 				Complete: true,
 			},
 		})
@@ -356,11 +600,18 @@ func (p *Parser) onArrayEnd() {
 }
 
 func (p *Parser) onStringChunk(s string) {
+	if p.state == pObjAfterKey || p.state == pObjAfterValue {
+		// 宽松语法下这些位置不接受值，丢弃内容以免在 curString 中无界累积
+		return
+	}
 	p.curString.WriteString(s)
 
 	if p.state != pObjExpectKey {
 		p.curValueKind = valString
 		p.chunkBuffer.WriteString(s)
+		if p.shouldFlush(s) {
+			p.flushStringChunk()
+		}
 	}
 }
 
@@ -378,13 +629,62 @@ func (p *Parser) flushStringChunk() {
 		Type:     EventFieldValue,
 		pathOpts: pathOptions{},
 		Value: &PartialValue{
-			Kind:   ValueString,
-			Value:  chunk,
-			Append: true,
+			Kind:        ValueString,
+			Value:       chunk,
+			Append:      true,
+			accumulated: p.curString.String(),
 		},
 	})
 
 	p.chunkBuffer.Reset()
+	p.chunkRunes = 0
+	if p.opts.Flush.Mode == FlushOnInterval {
+		p.lastFlush = p.now()
+	}
+}
+
+// flushKeyChunk 将未结束 key 新增的部分作为增量 EventKey 发往所在对象的路径
+func (p *Parser) flushKeyChunk() {
+	if !p.opts.PartialKeys || p.state != pObjExpectKey || p.curString.Len() <= p.keyFlushed {
+		return
+	}
+
+	chunk := p.curString.String()[p.keyFlushed:]
+	p.keyFlushed = p.curString.Len()
+	p.emit(Event{
+		Type:     EventKey,
+		pathOpts: pathOptions{excludeTop: true},
+		Value: &PartialValue{
+			Kind:        ValueString,
+			Value:       chunk,
+			Append:      true,
+			accumulated: p.curString.String(),
+		},
+	})
+}
+
+// emitFieldStart 在对象字段的值开始时发出 EventFieldStart，Value.Kind 为即将到来的值类型
+func (p *Parser) emitFieldStart(tt TokenType) {
+	var kind ValueKind
+	switch tt {
+	case TokenLBrace:
+		kind = ValueObject
+	case TokenLBracket:
+		kind = ValueArray
+	case TokenStringChunk, TokenStringEnd:
+		kind = ValueString
+	case TokenNumberChunk:
+		kind = ValueNumber
+	case TokenBool:
+		kind = ValueBool
+	case TokenNull:
+		kind = ValueNull
+	}
+	p.emit(Event{
+		Type:     EventFieldStart,
+		pathOpts: pathOptions{},
+		Value:    &PartialValue{Kind: kind},
+	})
 }
 
 func (p *Parser) onStringEnd() {
@@ -392,14 +692,31 @@ func (p *Parser) onStringEnd() {
 	case pObjExpectKey:
 		top := p.stack.top()
 		if top == nil {
-			p.err = ErrUnexpectedToken
+			p.fail(ErrUnexpectedToken, nil)
 			return
 		}
+		if p.keyFlushed > 0 {
+			p.flushKeyChunk()
+		}
 		top.key = p.curString.String()
+		top.keys++
+		top.seeKey(top.key)
 		p.segmentsDirty = true
 		p.curString.Reset()
+		p.keyFlushed = 0
 		p.state = pObjAfterKey
-	case pObjExpectValue, pArrExpectValue:
+		if p.opts.KeyEvents {
+			p.emit(Event{
+				Type:     EventKey,
+				pathOpts: pathOptions{},
+				Value: &PartialValue{
+					Kind:     ValueString,
+					Value:    top.key,
+					Complete: true,
+				},
+			})
+		}
+	case pObjExpectValue, pArrExpectValue, pArrAfterValue, pIdle:
 		if p.chunkBuffer.Len() > 0 {
 			bufferLen := p.chunkBuffer.Len()
 			curStringLen := p.curString.Len()
@@ -408,17 +725,21 @@ func (p *Parser) onStringEnd() {
 			}
 		}
 
+		val := p.curString.String()
 		p.emit(Event{
 			Type:     EventFieldValue,
 			pathOpts: pathOptions{},
 			Value: &PartialValue{
 				Kind:     ValueString,
-				Value:    p.curString.String(),
+				Value:    val,
 				Complete: true,
 			},
 		})
+		p.attachValue(val)
 		p.curString.Reset()
 		p.chunkBuffer.Reset()
+		p.chunkRunes = 0
+		p.lastFlush = time.Time{}
 		p.curValueKind = valNone
 		p.lastValueKind = ValueString
 		p.advanceAfterValue()
@@ -430,9 +751,36 @@ func (p *Parser) onNumberChunk(s string) {
 	p.curNumber.WriteString(s)
 }
 
+// flushNumberChunk 将未结束数字新增的部分作为 Append 事件发出
+func (p *Parser) flushNumberChunk() {
+	if !p.opts.PartialNumbers || p.curValueKind != valNumber {
+		return
+	}
+	if p.curNumber.Len() <= p.numberFlushed {
+		return
+	}
+
+	chunk := p.curNumber.String()[p.numberFlushed:]
+	p.numberFlushed = p.curNumber.Len()
+	p.emit(Event{
+		Type:     EventFieldValue,
+		pathOpts: pathOptions{},
+		Value: &PartialValue{
+			Kind:        ValueNumber,
+			Value:       chunk,
+			Append:      true,
+			accumulated: p.curNumber.String(),
+		},
+	})
+}
+
 func (p *Parser) onNumberEnd() {
+	if p.numberFlushed > 0 {
+		p.flushNumberChunk()
+	}
 	val := p.curNumber.String()
 	p.curNumber.Reset()
+	p.numberFlushed = 0
 	p.curValueKind = valNone
 	p.lastValueKind = ValueNumber
 	p.emit(Event{
@@ -440,10 +788,11 @@ func (p *Parser) onNumberEnd() {
 		pathOpts: pathOptions{},
 		Value: &PartialValue{
 			Kind:     ValueNumber,
-			Value:    val,
+			Value:    json.Number(val),
 			Complete: true,
 		},
 	})
+	p.attachValue(json.Number(val))
 	p.advanceAfterValue()
 }
 
@@ -462,6 +811,7 @@ func (p *Parser) onPrimitive(v any) {
 			Complete: true,
 		},
 	})
+	p.attachValue(v)
 	p.advanceAfterValue()
 }
 
@@ -472,8 +822,7 @@ func (p *Parser) onColon() {
 func (p *Parser) onComma() {
 	top := p.stack.top()
 	if top == nil {
-		p.err = ErrUnexpectedToken
-		p.ob.OnError(p.err, func() map[string]any {
+		p.fail(ErrUnexpectedToken, func() map[string]any {
 			return map[string]any{
 				"action":      "comma",
 				"stack_empty": true,
@@ -500,6 +849,7 @@ func (p *Parser) advanceAfterValue() {
 	top := p.stack.top()
 	if top == nil {
 		p.state = pIdle
+		p.endDocument()
 		return
 	}
 	switch top.kind {
@@ -511,6 +861,7 @@ func (p *Parser) advanceAfterValue() {
 			pathOpts: pathOptions{},
 			Value: &PartialValue{
 				Kind:     p.lastValueKind,
+				Value:    lastItem(top),
 				Complete: true,
 			},
 		})
@@ -520,19 +871,115 @@ func (p *Parser) advanceAfterValue() {
 	}
 }
 
-func (p *Parser) emitStreamEnd() {
-	if p.state == pIdle &&
-		p.curValueKind == valNone &&
-		p.tokenizer.state == tIdle {
-		p.emit(Event{
-			Type:     EventStreamEnd,
-			pathOpts: pathOptions{},
-		})
+// expectsValue 判断当前状态是否在等待一个值
+func (p *Parser) expectsValue() bool {
+	return p.state == pIdle || p.state == pObjExpectValue || p.state == pArrExpectValue
+}
+
+// checkGrammar 在启用错误恢复或严格语法时检查 token 是否合法，
+// 否则保持宽松解析：缺失的逗号、冒号等不会报错
+func (p *Parser) checkGrammar(tt TokenType) error {
+	if !p.opts.Recovery && !p.opts.Strict {
+		return nil
+	}
+	return p.checkToken(tt)
+}
+
+// checkToken 检查 token 在当前状态下是否合法
+func (p *Parser) checkToken(tt TokenType) error {
+	switch tt {
+	case TokenRBrace:
+		if top := p.stack.top(); top == nil || top.kind != frameObject {
+			return ErrMismatchedBrace
+		}
+		// 允许尾随逗号 {"a": 1,}
+		if p.state == pObjExpectKey || p.state == pObjAfterValue {
+			return nil
+		}
+	case TokenRBracket:
+		if top := p.stack.top(); top == nil || top.kind != frameArray {
+			return ErrMismatchedBracket
+		}
+		if p.state == pArrExpectValue || p.state == pArrAfterValue {
+			return nil
+		}
+	case TokenColon:
+		if p.state == pObjAfterKey {
+			return nil
+		}
+	case TokenComma:
+		if p.state == pObjAfterValue || p.state == pArrAfterValue {
+			return nil
+		}
+	case TokenNumberEnd:
+		if p.curValueKind == valNumber {
+			return nil
+		}
+	case TokenStringChunk, TokenStringEnd:
+		if p.state == pObjExpectKey || p.expectsValue() {
+			return nil
+		}
+	default:
+		if p.expectsValue() {
+			return nil
+		}
 	}
+	return ErrUnexpectedToken
+}
+
+// isValueStart 判断 token 是否会开始一个值
+func isValueStart(tt TokenType) bool {
+	switch tt {
+	case TokenLBrace, TokenLBracket, TokenStringChunk, TokenStringEnd,
+		TokenNumberChunk, TokenBool, TokenNull:
+		return true
+	}
+	return false
+}
+
+func (p *Parser) beginDocument() {
+	p.inDocument = true
+	p.emit(Event{
+		Type:     EventDocumentStart,
+		pathOpts: pathOptions{},
+	})
+}
+
+func (p *Parser) endDocument() {
+	if !p.inDocument {
+		return
+	}
+	p.emit(Event{
+		Type:     EventDocumentEnd,
+		pathOpts: pathOptions{},
+		Value:    p.materializedValue(valueKindOf(p.rootValue), p.rootValue),
+	})
+	p.rootValue = nil
+	p.inDocument = false
+	p.docIndex++
+	p.segmentsDirty = true
+	p.dropScopes(0)
+}
+
+// emitStreamEnd 在根路径上发出 EventStreamEnd，err 非 nil 表示输入在值中途结束
+// endRootContainer 在顶层对象/数组结束后结束文档，启用 RootStreamEnd 时随即发出 EventStreamEnd
+func (p *Parser) endRootContainer() {
+	p.endDocument()
+	if p.opts.RootStreamEnd {
+		p.emitStreamEnd(nil)
+	}
+}
+
+func (p *Parser) emitStreamEnd(err error) {
+	p.emit(Event{
+		Type:     EventStreamEnd,
+		pathOpts: pathOptions{frame: 1},
+		Err:      err,
+	})
 }
 
 func (p *Parser) checkState() error {
-	if p.tokenizer == nil {
+	if p.tokenizer == nil || p.closed {
 		return ErrInvalidState
 	}
 	return p.err
@@ -545,19 +992,171 @@ func (p *Parser) Feed(data []byte) error {
 
 // FeedString 输入字符串数据
 func (p *Parser) FeedString(s string) error {
+	return p.feed(context.Background(), s)
+}
+
+// FeedContext 输入字节数据，ctx 结束时停止解析，ctx 的错误由 Feed 和 Parser.Err 返回
+func (p *Parser) FeedContext(ctx context.Context, data []byte) error {
+	return p.feed(ctx, string(data))
+}
+
+// FeedStringContext 输入字符串数据，ctx 结束时停止解析
+func (p *Parser) FeedStringContext(ctx context.Context, s string) error {
+	return p.feed(ctx, s)
+}
+
+// ReadFrom 从 r 读取并解析数据直到 EOF，实现 io.ReaderFrom
+// 读取到 EOF 后不会调用 Close，可以继续输入后续数据
+func (p *Parser) ReadFrom(r io.Reader) (int64, error) {
+	return p.ReadFromContext(context.Background(), r)
+}
+
+// ReadFromContext 与 ReadFrom 相同，ctx 结束时停止读取和解析
+// 正在阻塞的 Read 无法被打断，需要时应使用可被 ctx 取消的 Reader（例如 HTTP 响应体）
+func (p *Parser) ReadFromContext(ctx context.Context, r io.Reader) (int64, error) {
+	buf := make([]byte, 0, readBufferSize)
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			p.cancel(ctx)
+			return total, p.err
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		total += int64(n)
+		buf = buf[:len(buf)+n]
+
+		complete := len(buf)
+		if err == nil {
+			complete = fullRunes(buf)
+		}
+		if complete > 0 {
+			if ferr := p.feed(ctx, string(buf[:complete])); ferr != nil {
+				return total, ferr
+			}
+			buf = buf[:copy(buf, buf[complete:])]
+		}
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// ctxCheckInterval 解析过程中每隔多少个字符检查一次 ctx
+const ctxCheckInterval = 256
+
+// feed 解析一段输入，期间定期检查 ctx
+func (p *Parser) feed(ctx context.Context, s string) error {
 	if err := p.checkState(); err != nil {
 		return err
 	}
+	if max := p.opts.Limits.MaxBytes; max > 0 && p.bytes+len(s) > max {
+		p.abort(fmt.Errorf("%w: limit %d", ErrInputTooLarge, max), nil)
+		return p.err
+	}
+	p.adoptFutures()
+	done := ctx.Done()
+	if done != nil && ctx.Err() != nil {
+		p.cancel(ctx)
+		return p.err
+	}
+	p.bytes += len(s)
+	n := 0
 	for _, r := range s {
+		n++
+		if done != nil && n%ctxCheckInterval == 0 && ctx.Err() != nil {
+			p.cancel(ctx)
+			return p.err
+		}
 		p.tokenizer.Consume(r)
+		if err := p.tokenizer.Err(); err != nil && p.err == nil {
+			p.abort(err, nil)
+		}
+		if p.err != nil {
+			return p.err
+		}
+	}
+	if p.flushAtFeedEnd() {
+		p.flushStringChunk()
+	}
+	p.flushNumberChunk()
+	p.flushKeyChunk()
+	return p.err
+}
+
+// cancel 因 ctx 结束而停止解析
+func (p *Parser) cancel(ctx context.Context) {
+	p.abort(context.Cause(ctx), func() map[string]any {
+		return map[string]any{
+			"action": "cancel",
+			"bytes":  p.bytes,
+		}
+	})
+}
+
+// Close 结束输入：补全顶层末尾未终止的数字（例如 `42`），并在根路径上发出 EventStreamEnd
+// 输入在值中途结束时（仍有未关闭的容器，或顶层字符串/关键字未结束），未完成的值不会作为完整值发出，
+// EventStreamEnd 的 Err 以及 Close 和 Parser.Err 的返回值为 ErrUnexpectedEOF 或 ErrUnclosedString
+func (p *Parser) Close() error {
+	if err := p.checkState(); err != nil {
+		return err
+	}
+	// 不完整的顶层数字（如单独的 "-"）不补全，由 unfinished 报告
+	if len(p.stack) == 0 && p.tokenizer.state == tNumber && isJSONNumber(p.curNumber.String()) {
+		p.tokenizer.Close()
 		if p.err != nil {
 			return p.err
 		}
 	}
 	p.flushStringChunk()
+	p.checkOpenObjects()
+	err := p.unfinished()
+	p.closed = true
+	if err != nil || !p.opts.RootStreamEnd {
+		p.emitStreamEnd(err)
+	} else {
+		// EventStreamEnd 已在顶层容器结束时发出，这里只结束等待中的 Future 和事件通道
+		p.rejectFutures(nil)
+		p.endSinks()
+	}
+	if err != nil && p.err == nil {
+		p.ob.OnError(err, func() map[string]any {
+			return map[string]any{
+				"action":      "close",
+				"stack_depth": len(p.stack),
+			}
+		})
+		p.err = err
+	}
+	return p.err
+}
+
+// unfinished 返回输入在值中途结束时的错误，输入完整时返回 nil
+func (p *Parser) unfinished() error {
+	if n := len(p.stack); n > 0 {
+		p.updateCachedSegments()
+		p.segmentsDirty = false
+		path := buildPath(p.frameSegments(n-1), p.opts.PathSyntax)
+		return fmt.Errorf("%w: %d unclosed containers, innermost at %q", ErrUnexpectedEOF, n, path)
+	}
+	switch p.tokenizer.state {
+	case tString, tStringEscape:
+		return ErrUnclosedString
+	case tKeyword:
+		return fmt.Errorf("%w: incomplete literal", ErrUnexpectedEOF)
+	case tNumber:
+		return fmt.Errorf("%w: incomplete number %q", ErrUnexpectedEOF, p.curNumber.String())
+	}
 	return nil
 }
 
+// Documents 返回已完成的顶层文档数量
+func (p *Parser) Documents() int {
+	return p.docIndex
+}
+
 // Err 返回解析过程中的错误
 func (p *Parser) Err() error {
 	return p.err
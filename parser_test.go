@@ -0,0 +1,463 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestParser_MultipleDocuments 测试连续的多个 JSON 文档
+func TestParser_MultipleDocuments(t *testing.T) {
+	var starts, ends []int
+	var ids []int64
+	p := NewParser()
+	p.On("$", func(ev Event) {
+		switch ev.Type {
+		case EventDocumentStart:
+			starts = append(starts, ev.Document)
+		case EventDocumentEnd:
+			ends = append(ends, ev.Document)
+		}
+	})
+	p.On("$.id", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			if ev.Document != len(ids) {
+				t.Errorf("expected Document=%d, got %d", len(ids), ev.Document)
+			}
+			ids = append(ids, ev.Value.Int64())
+		}
+	})
+
+	if err := p.FeedString(`{"id": 1}{"id": 2} {"id": 3}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("expected ids [1 2 3], got %v", ids)
+	}
+	if len(starts) != 3 || len(ends) != 3 {
+		t.Fatalf("expected 3 DocumentStart/End events, got %d/%d", len(starts), len(ends))
+	}
+	for i := range starts {
+		if starts[i] != i || ends[i] != i {
+			t.Errorf("document %d: start=%d end=%d", i, starts[i], ends[i])
+		}
+	}
+	if p.Documents() != 3 {
+		t.Errorf("expected Documents()=3, got %d", p.Documents())
+	}
+}
+
+// TestParser_NDJSON 测试按行分隔的 JSON 流
+func TestParser_NDJSON(t *testing.T) {
+	var names []string
+	p := NewParser()
+	p.On("$.items[*].name", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			names = append(names, ev.Value.String())
+		}
+	})
+
+	lines := []string{
+		`{"items": [{"name": "a"}]}` + "\n",
+		`{"items": [{"name": "b"}, {"name": "c"}]}` + "\n",
+	}
+	for _, line := range lines {
+		if err := p.FeedString(line); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	if len(names) != 3 || names[0] != "a" || names[1] != "b" || names[2] != "c" {
+		t.Errorf("expected names [a b c], got %v", names)
+	}
+}
+
+// TestParser_TopLevelScalars 测试顶层标量作为独立文档
+func TestParser_TopLevelScalars(t *testing.T) {
+	var values []string
+	var docEnds int
+	var streamEnd bool
+	p := NewParser()
+	p.On("$", func(ev Event) {
+		switch ev.Type {
+		case EventFieldValue:
+			if ev.Value != nil && ev.Value.Complete {
+				values = append(values, ev.Value.Kind.String()+":"+ev.Value.String())
+			}
+		case EventDocumentEnd:
+			docEnds++
+		case EventStreamEnd:
+			streamEnd = true
+		}
+	})
+
+	if err := p.FeedString(`"x" true null 42`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	// 末尾的数字没有分隔符，需要 Close 才能结束
+	if docEnds != 3 {
+		t.Errorf("expected 3 documents before Close, got %d", docEnds)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	want := []string{"String:x", "Bool:true", "Null:", "Number:42"}
+	if len(values) != len(want) {
+		t.Fatalf("expected %v, got %v", want, values)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("value[%d] = %q, want %q", i, values[i], want[i])
+		}
+	}
+	if docEnds != 4 {
+		t.Errorf("expected 4 documents, got %d", docEnds)
+	}
+	if !streamEnd {
+		t.Error("expected StreamEnd after Close")
+	}
+	if err := p.FeedString(`1`); err != ErrInvalidState {
+		t.Errorf("expected ErrInvalidState after Close, got %v", err)
+	}
+}
+
+// TestParser_CloseTruncated 测试输入在容器中途结束时 Close 的行为
+func TestParser_CloseTruncated(t *testing.T) {
+	var completes []string
+	var ends []Event
+	p := NewParser()
+	p.OnComplete("$.a.b", func(ev Event) {
+		completes = append(completes, ev.Value.String())
+	})
+	p.OnStreamEnd(func(ev Event) {
+		ends = append(ends, ev)
+	})
+	f := p.Future("$.a.b")
+
+	if err := p.FeedString(`{"a": {"b": "trunc`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	err := p.Close()
+	if !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("expected ErrUnexpectedEOF, got %v", err)
+	}
+	if !errors.Is(p.Err(), ErrUnexpectedEOF) {
+		t.Errorf("expected Err() to report ErrUnexpectedEOF, got %v", p.Err())
+	}
+	if len(completes) != 0 {
+		t.Errorf("truncated string must not be delivered as complete, got %q", completes)
+	}
+	if len(ends) != 1 || ends[0].Path() != "" || !errors.Is(ends[0].Err, ErrUnexpectedEOF) {
+		t.Fatalf("expected one StreamEnd on the root path carrying the error, got %+v", ends)
+	}
+	if _, ferr := f.Wait(context.Background()); !errors.Is(ferr, ErrUnexpectedEOF) {
+		t.Errorf("expected Future to fail with ErrUnexpectedEOF, got %v", ferr)
+	}
+}
+
+// TestParser_CloseTopLevelString 测试顶层未结束的字符串不会被当作完整值
+func TestParser_CloseTopLevelString(t *testing.T) {
+	var values []string
+	p := NewParser()
+	p.OnComplete("$", func(ev Event) {
+		values = append(values, ev.Value.String())
+	})
+	p.FeedString(`"abc`)
+	if err := p.Close(); !errors.Is(err, ErrUnclosedString) {
+		t.Fatalf("expected ErrUnclosedString, got %v", err)
+	}
+	if len(values) != 0 {
+		t.Errorf("expected no complete values, got %q", values)
+	}
+}
+
+// TestParser_CloseIncompleteNumber 测试顶层不完整的数字在 Close 时报告为 ErrUnexpectedEOF
+func TestParser_CloseIncompleteNumber(t *testing.T) {
+	for _, input := range []string{"-", "1.", "2e", "3e+"} {
+		var values []string
+		p := NewParser()
+		p.OnComplete("$", func(ev Event) {
+			values = append(values, ev.Value.String())
+		})
+		p.FeedString(input)
+		if err := p.Close(); !errors.Is(err, ErrUnexpectedEOF) {
+			t.Errorf("%q: expected ErrUnexpectedEOF, got %v", input, err)
+		}
+		if len(values) != 0 {
+			t.Errorf("%q: expected no complete values, got %q", input, values)
+		}
+	}
+}
+
+// TestParser_RootStreamEnd 测试 WithRootStreamEnd 恢复顶层容器结束时发出 EventStreamEnd 的旧行为
+func TestParser_RootStreamEnd(t *testing.T) {
+	var ends []Event
+	p := NewParser(WithRootStreamEnd())
+	p.OnStreamEnd(func(ev Event) { ends = append(ends, ev) })
+	ch := p.Events(context.Background())
+
+	if err := p.FeedString(`{"a": [1, 2]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if len(ends) != 1 || ends[0].Err != nil {
+		t.Fatalf("expected StreamEnd when the root object closes, got %+v", ends)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if len(ends) != 1 {
+		t.Errorf("Close must not repeat StreamEnd, got %d", len(ends))
+	}
+	var last Event
+	for ev := range ch {
+		last = ev
+	}
+	if last.Type != EventStreamEnd {
+		t.Errorf("expected channel to end with StreamEnd, got %s", last.Type)
+	}
+
+	// 输入不完整时 Close 仍然报告错误
+	ends = nil
+	p = NewParser(WithRootStreamEnd())
+	p.OnStreamEnd(func(ev Event) { ends = append(ends, ev) })
+	p.FeedString(`{"a": `)
+	if err := p.Close(); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Fatalf("expected ErrUnexpectedEOF, got %v", err)
+	}
+	if len(ends) != 1 || !errors.Is(ends[0].Err, ErrUnexpectedEOF) {
+		t.Errorf("expected one StreamEnd carrying the error, got %+v", ends)
+	}
+}
+
+// TestParser_PartialNumbers 测试未结束的数字按 Feed 发出增量事件
+func TestParser_PartialNumbers(t *testing.T) {
+	var chunks []string
+	var final string
+	p := NewParser()
+	p.EnablePartialNumbers()
+	p.On("$.progress", func(ev Event) {
+		if ev.Value == nil {
+			return
+		}
+		if ev.Value.Append {
+			if ev.Value.Complete {
+				t.Error("partial number event must not be complete")
+			}
+			chunks = append(chunks, ev.Value.String())
+		}
+		if ev.Value.Complete {
+			final = ev.Value.String()
+		}
+	})
+
+	for _, frag := range []string{`{"progress": 1`, `2`, `3.5`, `}`} {
+		if err := p.FeedString(frag); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	want := []string{"1", "2", "3.5"}
+	if len(chunks) != len(want) {
+		t.Fatalf("expected chunks %v, got %v", want, chunks)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("chunk[%d] = %q, want %q", i, chunks[i], want[i])
+		}
+	}
+	if final != "123.5" {
+		t.Errorf("expected final value 123.5, got %q", final)
+	}
+}
+
+// TestParser_PartialNumbersDisabled 测试默认不发出数字增量事件
+func TestParser_PartialNumbersDisabled(t *testing.T) {
+	var events int
+	p := NewParser()
+	p.On("$.n", func(ev Event) {
+		events++
+	})
+	if err := p.FeedString(`{"n": 12`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if events != 0 {
+		t.Errorf("expected no events before the number ends, got %d", events)
+	}
+}
+
+// TestParser_KeyEvents 测试 key 确定后、值到来前发出字段事件
+func TestParser_KeyEvents(t *testing.T) {
+	var seq []string
+	p := NewParser()
+	p.EnableKeyEvents()
+	p.On("$.summary", func(ev Event) {
+		switch ev.Type {
+		case EventKey:
+			seq = append(seq, "key:"+ev.Value.String())
+		case EventFieldStart:
+			seq = append(seq, "start:"+ev.Value.Kind.String())
+		case EventFieldValue:
+			if ev.Value.Complete {
+				seq = append(seq, "value:"+ev.Value.String())
+			}
+		}
+	})
+
+	for _, frag := range []string{`{"id": 1, "summ`, `ary"`, `: "do`, `ne"}`} {
+		if err := p.FeedString(frag); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	want := []string{"key:summary", "start:String", "value:done"}
+	if len(seq) != len(want) {
+		t.Fatalf("expected %v, got %v", want, seq)
+	}
+	for i := range want {
+		if seq[i] != want[i] {
+			t.Errorf("event[%d] = %q, want %q", i, seq[i], want[i])
+		}
+	}
+}
+
+// TestParser_PartialKeys 测试未结束的 key 发往所在对象路径
+func TestParser_PartialKeys(t *testing.T) {
+	var chunks []string
+	var keyPath string
+	p := NewParser()
+	p.EnablePartialKeys()
+	p.On("$.data", func(ev Event) {
+		if ev.Type == EventKey && ev.Value.Append {
+			chunks = append(chunks, ev.Value.String())
+		}
+	})
+	p.On("$.data.description", func(ev Event) {
+		if ev.Type == EventKey && ev.Value.Complete {
+			keyPath = ev.Path()
+		}
+	})
+
+	for _, frag := range []string{`{"data": {"a": 1, "desc`, `ript`, `ion": "x"}}`} {
+		if err := p.FeedString(frag); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	want := []string{"desc", "ript", "ion"}
+	if len(chunks) != len(want) {
+		t.Fatalf("expected %v, got %v", want, chunks)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("chunk[%d] = %q, want %q", i, chunks[i], want[i])
+		}
+	}
+	if keyPath != "$.data.description" {
+		t.Errorf("expected complete key at $.data.description, got %q", keyPath)
+	}
+}
+
+// TestParser_ContainerEventPaths 测试 WithContainerPath 的订阅按容器自身路径接收容器事件
+func TestParser_ContainerEventPaths(t *testing.T) {
+	var types []EventType
+	p := NewParser()
+	p.On("$.tasks[*]", func(ev Event) {
+		types = append(types, ev.Type)
+		if ev.Path() != "$.tasks[0]" {
+			t.Errorf("unexpected path %q for %v", ev.Path(), ev.Type)
+		}
+	}, WithContainerPath())
+	if err := p.FeedString(`{"tasks": [{"steps": [1]}]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []EventType{EventObjectStart, EventObjectEnd, EventArrayItem}
+	if len(types) != len(want) {
+		t.Fatalf("expected %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("event[%d] = %v, want %v", i, types[i], want[i])
+		}
+	}
+}
+
+// TestParser_ContainerEventRouting 固定容器事件的路由：ObjectEnd/ArrayStart/ArrayEnd 默认按事件发生时的完整路径匹配，
+// 例如 ArrayStart 发往 $.items[*]、ObjectEnd 发往对象最后一个字段的订阅（$.items[*].y），
+// 根对象的 ObjectEnd 发往最后一个字段 $.last；ev.Path() 始终是容器自身的路径
+// WithContainerPath 和 OnObjectEnd 等类型化订阅则只投递给匹配容器自身路径的订阅
+func TestParser_ContainerEventRouting(t *testing.T) {
+	const input = `{"items":[{"x":1},{"y":2}],"last":{"z":3}}`
+	exprs := []string{"$", "$.items", "$.items[*]", "$.items[*].y", "$.last"}
+	containerTypes := []EventType{EventObjectStart, EventObjectEnd, EventArrayStart, EventArrayEnd}
+	ownPath := []string{
+		"$ ObjectStart ",
+		"$.items ArrayStart $.items",
+		"$.items[*] ObjectStart $.items[0]",
+		"$.items[*] ObjectEnd $.items[0]",
+		"$.items[*] ObjectStart $.items[1]",
+		"$.items[*] ObjectEnd $.items[1]",
+		"$.items ArrayEnd $.items",
+		"$.last ObjectStart $.last",
+		"$.last ObjectEnd $.last",
+		"$ ObjectEnd ",
+	}
+
+	tests := []struct {
+		name      string
+		subscribe func(p *Parser, expr string, h Handler)
+		want      []string
+	}{
+		{
+			name: "On",
+			subscribe: func(p *Parser, expr string, h Handler) {
+				p.On(expr, h, WithEventTypes(containerTypes...))
+			},
+			want: []string{
+				"$ ObjectStart ",
+				"$.items[*] ArrayStart $.items",
+				"$.items[*] ObjectStart $.items[0]",
+				"$.items[*] ObjectStart $.items[1]",
+				"$.items[*].y ObjectEnd $.items[1]",
+				"$.items[*] ArrayEnd $.items",
+				"$.last ObjectStart $.last",
+				"$.last ObjectEnd ",
+			},
+		},
+		{
+			name: "WithContainerPath",
+			subscribe: func(p *Parser, expr string, h Handler) {
+				p.On(expr, h, WithEventTypes(containerTypes...), WithContainerPath())
+			},
+			want: ownPath,
+		},
+		{
+			name: "typed",
+			subscribe: func(p *Parser, expr string, h Handler) {
+				p.OnObjectStart(expr, h).OnObjectEnd(expr, h).OnArrayStart(expr, h).OnArrayEnd(expr, h)
+			},
+			want: ownPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			p := NewParser()
+			for _, expr := range exprs {
+				tt.subscribe(p, expr, func(ev Event) {
+					got = append(got, expr+" "+ev.Type.String()+" "+ev.Path())
+				})
+			}
+			if err := p.FeedString(input); err != nil {
+				t.Fatalf("FeedString() failed: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
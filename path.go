@@ -10,6 +10,7 @@ import (
 type pathOptions struct {
 	excludeTop      bool // 是否排除顶层 frame（用于 ObjectEnd/ArrayEnd）
 	excludeTopIndex bool // 是否排除顶层 array 的 index（用于 ArrayStart）
+	frame           int  // 非 0 时使用栈中第 frame-1 个帧所在容器的路径（用于 EventMissingFields 和 EventStreamEnd）
 }
 
 func buildPathFromSegments(segments []PathSegment, opt pathOptions) string {
@@ -47,6 +48,118 @@ func buildPathFromSegments(segments []PathSegment, opt pathOptions) string {
 	return sb.String()
 }
 
+// PathSyntax 表示订阅表达式和 Event.Path 使用的路径语法
+type PathSyntax int
+
+const (
+	// PathJSONPath 类 JSONPath 语法（默认），如 $.items[0].id、$.items[*].id
+	PathJSONPath PathSyntax = iota
+	// PathJSONPointer JSON Pointer 语法（RFC 6901），如 /items/0/id，以 * 作为通配符
+	// 纯数字的段视为数组索引
+	PathJSONPointer
+)
+
+// String 返回路径语法的字符串表示
+func (ps PathSyntax) String() string {
+	switch ps {
+	case PathJSONPath:
+		return "JSONPath"
+	case PathJSONPointer:
+		return "JSONPointer"
+	default:
+		return fmt.Sprintf("PathSyntax(%d)", ps)
+	}
+}
+
+// buildPath 按指定语法构建路径字符串
+func buildPath(segments []PathSegment, syntax PathSyntax) string {
+	if syntax == PathJSONPointer {
+		return buildPointerFromSegments(segments)
+	}
+	return buildPathFromSegments(segments, pathOptions{})
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func buildPointerFromSegments(segments []PathSegment) string {
+	var sb strings.Builder
+	for _, seg := range segments {
+		sb.WriteString("/")
+		sb.WriteString(pointerEscaper.Replace(seg.Value))
+	}
+	return sb.String()
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// CompilePointer 编译 JSON Pointer 形式的路径模式，空字符串表示根
+func CompilePointer(expr string) (PathPattern, error) {
+	if expr == "" {
+		return PathPattern{Segments: []PathSegment{}}, nil
+	}
+	if !strings.HasPrefix(expr, "/") {
+		return PathPattern{}, fmt.Errorf("%w: pointer must start with /", ErrInvalidPattern)
+	}
+
+	tokens := strings.Split(expr[1:], "/")
+	segments := make([]PathSegment, 0, len(tokens))
+	for _, tok := range tokens {
+		switch {
+		case tok == "*":
+			segments = append(segments, PathSegment{Kind: SegWildcard})
+		case isIndexToken(tok):
+			segments = append(segments, PathSegment{Kind: SegIndex, Value: tok})
+		default:
+			segments = append(segments, PathSegment{Kind: SegField, Value: pointerUnescaper.Replace(tok)})
+		}
+	}
+	return PathPattern{Segments: segments}, nil
+}
+
+func isIndexToken(tok string) bool {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return false
+	}
+	for _, r := range tok {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compilePatternSyntax 按指定语法编译路径模式
+func compilePatternSyntax(expr string, syntax PathSyntax) (PathPattern, error) {
+	if syntax == PathJSONPointer {
+		return CompilePointer(expr)
+	}
+	return CompilePattern(expr)
+}
+
+// compileRelative 编译相对路径模式并拼接在 prefix 之后
+// JSONPath 语法下相对模式以 . 或 [ 开头，JSON Pointer 语法下以 / 开头，空字符串表示 prefix 本身
+func compileRelative(prefix []PathSegment, expr string, syntax PathSyntax) (PathPattern, error) {
+	var rel PathPattern
+	var err error
+	if syntax == PathJSONPointer {
+		rel, err = CompilePointer(expr)
+	} else {
+		expr = strings.TrimSpace(expr)
+		if strings.HasPrefix(expr, "$") {
+			return PathPattern{}, fmt.Errorf("%w: relative pattern must not start with $", ErrInvalidPattern)
+		}
+		rel, err = CompilePattern("$" + expr)
+	}
+	if err != nil {
+		return PathPattern{}, err
+	}
+
+	segments := make([]PathSegment, 0, len(prefix)+len(rel.Segments))
+	segments = append(segments, prefix...)
+	segments = append(segments, rel.Segments...)
+	return PathPattern{Segments: segments}, nil
+}
+
 // SegmentKind 表示路径段的类型
 type SegmentKind int
 
@@ -175,3 +288,16 @@ func (p *Parser) updateCachedSegments() {
 	}
 	p.cachedSegments = segments
 }
+
+// containerSegments 返回栈顶容器自身的路径（不含栈顶帧贡献的 key 或 index）
+// 用于 ObjectEnd/ArrayStart/ArrayEnd 等容器事件，以及尚未确定的 key
+func (p *Parser) containerSegments() []PathSegment {
+	top := p.stack.top()
+	if top == nil {
+		return p.cachedSegments
+	}
+	if top.kind == frameArray || top.key != "" {
+		return p.cachedSegments[:len(p.cachedSegments)-1]
+	}
+	return p.cachedSegments
+}
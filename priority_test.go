@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParser_Priority(t *testing.T) {
+	var order []string
+	record := func(name string) Handler {
+		return func(Event) { order = append(order, name) }
+	}
+
+	p := NewParser()
+	p.OnComplete("$.a", record("render"))
+	p.OnComplete("$.a", record("audit"), WithPriority(-1))
+	p.OnComplete("$.a", record("validate"), WithPriority(10))
+	p.OnComplete("$.a", record("render2"))
+	p.OnComplete("$.a", record("validate2"), WithPriority(10))
+
+	if err := p.FeedString(`{"a": 1}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	want := "validate validate2 render render2 audit"
+	if got := strings.Join(order, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestParser_StopPropagation(t *testing.T) {
+	var rendered []string
+	p := NewParser()
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventFieldValue {
+			rendered = append(rendered, ev.Value.String())
+		}
+	})
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventFieldValue && ev.Value.String() == "bad" {
+			ev.StopPropagation()
+		}
+	}, WithPriority(1))
+	p.OnE("$.items[*]", func(ev Event) error {
+		if ev.Type == EventFieldValue && ev.Value.String() == "hidden" {
+			return ErrStopPropagation
+		}
+		return nil
+	}, WithPriority(2))
+
+	if err := p.FeedString(`{"items": ["ok", "bad", "hidden", "fine"]}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if got := strings.Join(rendered, ","); got != "ok,fine" {
+		t.Errorf("expected blocked items to be skipped, got %q", got)
+	}
+}
+
+func TestParserTemplate_Priority(t *testing.T) {
+	var order []string
+	tmpl, _ := NewParserTemplate()
+	tmpl.On("$", func(Event) { order = append(order, "low") })
+	tmpl.On("$", func(Event) { order = append(order, "high") }, WithPriority(1))
+
+	p := tmpl.NewParser()
+	p.On("$", func(Event) { order = append(order, "mid") }, WithPriority(0))
+	p.FeedString(`1`)
+
+	if got := strings.Join(order, " "); !strings.HasPrefix(got, "high low mid") {
+		t.Errorf("expected priority order across template and parser, got %q", got)
+	}
+}
+
+// TestParser_SubscribeDuringDispatch 测试 Handler 中注册更高优先级的订阅不会打乱当前事件的投递
+func TestParser_SubscribeDuringDispatch(t *testing.T) {
+	var order []string
+	p := NewParser()
+	registered := false
+	p.OnComplete("$.a", func(ev Event) {
+		order = append(order, "A")
+		if !registered {
+			registered = true
+			p.OnComplete("$.a", func(Event) { order = append(order, "C") }, WithPriority(10))
+			p.Group("$").On(".a", func(Event) { order = append(order, "D") }, WithPriority(10), WithEventTypes(EventFieldValue))
+		}
+	})
+	p.OnComplete("$.a", func(Event) { order = append(order, "B") })
+
+	p.FeedString(`{"a": 1}`)
+	if want := []string{"A", "B"}; !slices.Equal(order, want) {
+		t.Fatalf("expected %v during the first event, got %v", want, order)
+	}
+
+	order = order[:0]
+	p.FeedString(`{"a": 2}`)
+	if want := []string{"C", "D", "A", "B"}; !slices.Equal(order, want) {
+		t.Errorf("expected %v after registration, got %v", want, order)
+	}
+}
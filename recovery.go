@@ -0,0 +1,135 @@
+package stream
+
+// fail 记录一个解析错误
+// 未启用恢复时错误是致命的；启用恢复时发出 EventError 并开始重新同步
+func (p *Parser) fail(err error, context DebugContext) {
+	p.ob.OnError(err, context)
+	if !p.opts.Recovery {
+		p.err = err
+		p.failSinks(err)
+		p.rejectFutures(err)
+		return
+	}
+	p.resync(err)
+}
+
+// resync 丢弃当前损坏的元素，定位到最近的外层数组（没有则回到顶层），
+// 之后的 token 会被跳过，直到遇到下一个兄弟元素或下一个顶层文档
+func (p *Parser) resync(err error) {
+	keep := 0
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		if p.stack[i].kind == frameArray {
+			keep = i + 1
+			break
+		}
+	}
+
+	depth := len(p.stack) - keep
+	switch p.curToken {
+	case TokenLBrace, TokenLBracket:
+		depth++
+	case TokenRBrace, TokenRBracket:
+		depth--
+	}
+	if depth < 0 {
+		depth = 0
+	}
+
+	p.stack = p.stack[:keep]
+	p.segmentsDirty = true
+	if keep == 0 {
+		p.dropScopes(0)
+	} else {
+		p.dropScopes(keep + 1)
+	}
+	p.curString.Reset()
+	p.curNumber.Reset()
+	p.numberFlushed = 0
+	p.keyFlushed = 0
+	p.chunkBuffer.Reset()
+	p.chunkRunes = 0
+	p.curValueKind = valNone
+
+	p.emit(Event{
+		Type:     EventError,
+		pathOpts: pathOptions{},
+		Err:      err,
+	})
+
+	oldState := p.state
+	p.skipping = true
+	p.skipDepth = depth
+	if keep == 0 {
+		p.state = pIdle
+		p.rootValue = nil
+		p.endBrokenDocument(err)
+		if depth == 0 {
+			p.skipping = false
+		}
+	} else {
+		p.state = pArrAfterValue
+		// 多余的逗号本身即可作为分隔符，例如 [1,,2]
+		if p.curToken == TokenComma && depth == 0 {
+			p.skipping = false
+			p.resumeArray()
+			p.state = pArrExpectValue
+		}
+	}
+	p.ob.OnStateChange(oldState, p.state, func() map[string]any {
+		return map[string]any{
+			"action":      "resync",
+			"stack_depth": len(p.stack),
+			"skip_depth":  depth,
+		}
+	})
+}
+
+// endBrokenDocument 为已开始但损坏的顶层文档发出携带错误的 EventDocumentEnd，
+// 文档之外的错误（例如多余的 `}`）不占用文档序号
+func (p *Parser) endBrokenDocument(err error) {
+	if !p.inDocument {
+		return
+	}
+	p.emit(Event{
+		Type:     EventDocumentEnd,
+		pathOpts: pathOptions{},
+		Err:      err,
+	})
+	p.inDocument = false
+	p.docIndex++
+}
+
+// skipToken 在重新同步期间消费一个 token
+func (p *Parser) skipToken(tt TokenType) {
+	switch tt {
+	case TokenLBrace, TokenLBracket:
+		p.skipDepth++
+		return
+	case TokenRBrace, TokenRBracket:
+		if p.skipDepth > 0 {
+			p.skipDepth--
+			if p.skipDepth == 0 && len(p.stack) == 0 {
+				p.skipping = false
+			}
+			return
+		}
+		// 闭合符属于外层数组，交还给正常流程处理
+		p.skipping = false
+		p.resumeArray()
+		p.OnToken(Token{Type: tt})
+	case TokenComma:
+		if p.skipDepth == 0 {
+			p.skipping = false
+			p.resumeArray()
+			p.state = pArrExpectValue
+		}
+	}
+}
+
+// resumeArray 将损坏的元素计入外层数组的索引
+func (p *Parser) resumeArray() {
+	if top := p.stack.top(); top != nil {
+		top.index++
+		p.segmentsDirty = true
+	}
+}
@@ -0,0 +1,182 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestRecovery_Disabled 测试未启用恢复时错误是致命的
+func TestRecovery_Disabled(t *testing.T) {
+	p := NewParser(WithStrict())
+	err := p.FeedString(`{"a": 1 "b": 2}`)
+	if !errors.Is(err, ErrUnexpectedToken) {
+		t.Fatalf("expected ErrUnexpectedToken, got %v", err)
+	}
+	if err := p.FeedString(`{"c": 3}`); err == nil {
+		t.Error("expected error to persist after failure")
+	}
+}
+
+// TestRecovery_LenientByDefault 测试默认的宽松解析容忍缺失的逗号和冒号，严格模式下报错
+func TestRecovery_LenientByDefault(t *testing.T) {
+	for _, input := range []string{`{"a":1 "b":2}`, `{"a" 1}`, `[1 2]`} {
+		if err := NewParser().FeedString(input); err != nil {
+			t.Errorf("%s: expected lenient parse, got %v", input, err)
+		}
+		if err := NewParser(WithStrict()).FeedString(input); !errors.Is(err, ErrUnexpectedToken) {
+			t.Errorf("%s: expected ErrUnexpectedToken in strict mode, got %v", input, err)
+		}
+	}
+
+	var values []string
+	p := NewParser()
+	p.OnComplete("$[*]", func(ev Event) {
+		values = append(values, ev.Value.String())
+	})
+	p.FeedString(`[1 2]`)
+	if len(values) != 2 || values[0] != "1" || values[1] != "2" {
+		t.Errorf("expected [1 2], got %q", values)
+	}
+}
+
+// TestRecovery_ArraySibling 测试跳过损坏的数组元素并在下一个兄弟元素处继续
+func TestRecovery_ArraySibling(t *testing.T) {
+	var ids []string
+	var errPaths []string
+	p := NewParser()
+	p.EnableRecovery()
+	p.On("$.items[*].id", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			ids = append(ids, ev.Path()+"="+ev.Value.String())
+		}
+	})
+	p.On("$.items[*]", func(ev Event) {
+		if ev.Type == EventError {
+			errPaths = append(errPaths, ev.Path())
+		}
+	})
+
+	input := `{"items": [{"id": 1}, {"id": 2 "broken": {"x": [1]}}, {"id": 3}]}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	// 出错前已经发出的事件不会被撤回
+	want := []string{"$.items[0].id=1", "$.items[1].id=2", "$.items[2].id=3"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("id[%d] = %q, want %q", i, ids[i], want[i])
+		}
+	}
+	if len(errPaths) != 1 || errPaths[0] != "$.items[1]" {
+		t.Errorf("expected one error at $.items[1], got %v", errPaths)
+	}
+	if p.Err() != nil {
+		t.Errorf("expected no fatal error, got %v", p.Err())
+	}
+}
+
+// TestRecovery_ScalarSibling 测试数组中缺少逗号和多余逗号的情况
+func TestRecovery_ScalarSibling(t *testing.T) {
+	var paths []string
+	p := NewParser()
+	p.EnableRecovery()
+	p.On("$[*]", func(ev Event) {
+		if ev.Type == EventFieldValue && ev.Value != nil && ev.Value.Complete {
+			paths = append(paths, ev.Path()+"="+ev.Value.String())
+		}
+	})
+
+	if err := p.FeedString(`[1 2, 3,, 4]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []string{"$[0]=1", "$[2]=3", "$[4]=4"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("value[%d] = %q, want %q", i, paths[i], want[i])
+		}
+	}
+}
+
+// TestRecovery_NextDocument 测试顶层损坏时在下一个文档处继续
+func TestRecovery_NextDocument(t *testing.T) {
+	var values []string
+	var errs []error
+	p := NewParser()
+	p.EnableRecovery()
+	p.On("$", func(ev Event) {
+		if ev.Type == EventError {
+			errs = append(errs, ev.Err)
+		}
+	})
+	p.On("$.b", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			values = append(values, ev.Value.String())
+		}
+	})
+
+	input := "{\"a\": 1 \"x\": {}}\n}\n{\"b\": \"ok\"}\n"
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !errors.Is(errs[0], ErrUnexpectedToken) || !errors.Is(errs[1], ErrMismatchedBrace) {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if len(values) != 1 || values[0] != "ok" {
+		t.Errorf("expected [ok], got %v", values)
+	}
+}
+
+// TestRecovery_DocumentBoundaries 测试损坏的文档有对应的 DocumentEnd，文档之外的错误不占用文档序号
+func TestRecovery_DocumentBoundaries(t *testing.T) {
+	var events []string
+	p := NewParser(WithRecovery())
+	p.On("$", func(ev Event) {
+		switch ev.Type {
+		case EventDocumentStart, EventDocumentEnd, EventError:
+			events = append(events, fmt.Sprintf("%d %s err=%v", ev.Document, ev.Type, ev.Err != nil))
+		}
+	})
+	p.On("$.a", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			events = append(events, fmt.Sprintf("%d a=%s", ev.Document, ev.Value.String()))
+		}
+	})
+
+	if err := p.FeedString(`}{"a":7}{"a": 1 "x"}{"a":8}`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []string{
+		"0 Error err=true",
+		"0 DocumentStart err=false",
+		"0 a=7",
+		"0 DocumentEnd err=false",
+		"1 DocumentStart err=false",
+		"1 a=1",
+		"1 Error err=true",
+		"1 DocumentEnd err=true",
+		"2 DocumentStart err=false",
+		"2 a=8",
+		"2 DocumentEnd err=false",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("expected %q, got %q", want, events)
+	}
+	if p.Documents() != 3 {
+		t.Errorf("expected Documents()=3, got %d", p.Documents())
+	}
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Scope 表示一个容器实例（对象或数组）的作用域，通过 Event.Scope 获取
+// 在作用域上注册的订阅使用相对路径，只匹配该实例内部的事件，并在实例结束时自动移除
+type Scope struct {
+	p      *Parser
+	prefix []PathSegment // 容器自身的路径
+	depth  int           // 容器在结构帧栈中的深度，0 表示顶层文档
+	id     uint64        // 容器实例编号
+	doc    int           // 所属文档序号
+}
+
+// Scope 返回当前事件所在容器实例的作用域：ObjectStart/ArrayStart 为新开始的容器，
+// 字段值等其他事件为包含它的容器，栈为空时为当前顶层文档
+// 只能在 Handler 执行期间调用，其他情况返回 nil；OnUnion 重放的事件返回事件发出时的作用域
+func (ev *Event) Scope() *Scope {
+	if ev.scope != nil {
+		return ev.scope
+	}
+	p := ev.parser
+	if p == nil {
+		return nil
+	}
+	s := &Scope{
+		p:      p,
+		prefix: append([]PathSegment{}, p.containerSegments()...),
+		depth:  len(p.stack),
+		doc:    p.docIndex,
+	}
+	if top := p.stack.top(); top != nil {
+		s.id = top.id
+	}
+	return s
+}
+
+// Path 返回作用域容器的路径
+func (s *Scope) Path() string {
+	return buildPath(s.prefix, s.p.opts.PathSyntax)
+}
+
+// On 订阅作用域内相对路径的事件（JSONPath 语法下如 .steps[*]，JSON Pointer 语法下如 /steps/*），
+// 订阅在容器结束时自动移除；容器已结束时不做任何事
+func (s *Scope) On(expr string, h Handler, opts ...SubscribeOption) *Scope {
+	s.add(s.newSubscription(expr, h, opts))
+	return s
+}
+
+// OnE 与 On 相同，但处理函数可以返回错误（见 HandlerE）
+func (s *Scope) OnE(expr string, h HandlerE, opts ...SubscribeOption) *Scope {
+	sub := s.newSubscription(expr, nil, opts)
+	sub.handlerE = h
+	s.add(sub)
+	return s
+}
+
+// newSubscription 编译相对路径并创建作用域订阅，表达式非法时 panic
+func (s *Scope) newSubscription(expr string, h Handler, opts []SubscribeOption) *Subscription {
+	pat, err := compileRelative(s.prefix, expr, s.p.opts.PathSyntax)
+	if err != nil {
+		panic(fmt.Errorf("scope %s: %w", s.Path(), err))
+	}
+	sub := newPatternSubscription(s.Path()+expr, pat, h, opts)
+	sub.scope = s.depth + 1
+	return sub
+}
+
+// alive 判断作用域对应的容器实例是否仍未结束
+func (s *Scope) alive() bool {
+	p := s.p
+	if s.depth == 0 {
+		return p.inDocument && p.docIndex == s.doc
+	}
+	return len(p.stack) >= s.depth && p.stack[s.depth-1].id == s.id
+}
+
+// add 注册作用域订阅，投递事件期间注册的订阅在本次投递结束后生效
+func (s *Scope) add(sub *Subscription) {
+	if !s.alive() {
+		return
+	}
+	p := s.p
+	p.scoped++
+	p.subscribe(sub)
+}
+
+// subscribe 加入订阅；投递期间注册的订阅先暂存，投递结束后再加入，
+// 避免按优先级插入时移动正在遍历的订阅列表
+func (p *Parser) subscribe(sub *Subscription) {
+	if p.dispatching {
+		p.pendingSubs = append(p.pendingSubs, sub)
+		return
+	}
+	p.subs = addSubscription(p.subs, sub)
+}
+
+// endDispatch 结束投递并加入投递期间注册的订阅
+// 通过 defer 调用，Handler 的 panic 穿过 Feed 传播（PanicPropagate）时同样会执行，之后注册的订阅不会一直被暂存
+func (p *Parser) endDispatch() {
+	p.dispatching = false
+	if len(p.pendingSubs) > 0 {
+		p.addPendingSubs()
+	}
+}
+
+// addPendingSubs 加入投递期间注册的订阅
+func (p *Parser) addPendingSubs() {
+	for i, sub := range p.pendingSubs {
+		p.subs = addSubscription(p.subs, sub)
+		p.pendingSubs[i] = nil
+	}
+	p.pendingSubs = p.pendingSubs[:0]
+}
+
+// dropScopes 移除深度不小于 depth 的容器实例上注册的作用域订阅
+func (p *Parser) dropScopes(depth int) {
+	if p.scoped == 0 {
+		return
+	}
+	p.subs = slices.DeleteFunc(p.subs, func(sub *Subscription) bool {
+		if sub.scope <= depth {
+			return false
+		}
+		p.scoped--
+		delete(p.chains, sub)
+		return true
+	})
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEvent_Scope(t *testing.T) {
+	panels := map[string][]string{}
+	var stale *Scope
+
+	p := NewParser()
+	p.OnObjectStart("$.tasks[*]", func(ev Event) {
+		scope := ev.Scope()
+		panel := scope.Path()
+		scope.On(".steps[*]", func(ev Event) {
+			if ev.Value.Complete {
+				panels[panel] = append(panels[panel], ev.Value.String())
+			}
+		}, WithEventTypes(EventFieldValue))
+		stale = scope
+	})
+
+	input := `{"tasks": [{"name": "a", "steps": ["s1", "s2"]}, {"name": "b", "steps": ["t1"]}], "steps": ["x"]}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	if got := fmt.Sprint(panels); got != "map[$.tasks[0]:[s1 s2] $.tasks[1]:[t1]]" {
+		t.Errorf("unexpected routing: %s", got)
+	}
+	if len(p.subs) != 1 || p.scoped != 0 {
+		t.Errorf("expected scoped subscriptions to be removed, got %d subs", len(p.subs))
+	}
+
+	// 容器结束后在作用域上注册不做任何事
+	stale.On(".name", func(Event) {})
+	if len(p.subs) != 1 {
+		t.Error("expected registration on an ended scope to be ignored")
+	}
+}
+
+func TestEvent_ScopeEnclosing(t *testing.T) {
+	var got []string
+	p := NewParser(WithPathSyntax(PathJSONPointer))
+	p.OnComplete("/items/*/kind", func(ev Event) {
+		// 字段值的作用域是包含它的对象
+		if ev.Value.String() == "list" {
+			ev.Scope().On("/entries/*", func(ev Event) {
+				got = append(got, ev.Path()+"="+ev.Value.String())
+			}, WithEventTypes(EventFieldValue))
+		}
+	})
+
+	input := `{"items": [{"kind": "list", "entries": [1, 2]}, {"kind": "text", "entries": [3]}]}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if fmt.Sprint(got) != "[/items/0/entries/0=1 /items/0/entries/1=2]" {
+		t.Errorf("unexpected events: %v", got)
+	}
+
+	if (&Event{}).Scope() != nil {
+		t.Error("expected nil scope outside of handlers")
+	}
+}
+
+func TestEvent_ScopeRecovery(t *testing.T) {
+	count := 0
+	p := NewParser(WithRecovery())
+	p.OnObjectStart("$[*]", func(ev Event) {
+		ev.Scope().On(".v", func(Event) { count++ }, WithEventTypes(EventFieldValue))
+	})
+
+	if err := p.FeedString(`[{"v": 1, "x": }, {"v": 2}]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if count != 2 || p.scoped != 0 {
+		t.Errorf("expected scopes to be dropped with the broken element, count=%d scoped=%d", count, p.scoped)
+	}
+}
@@ -15,8 +15,12 @@ const (
 // frame 表示一个结构帧（用于维护解析上下文）
 type frame struct {
 	kind  frameKind // 帧类型（object 或 array）
+	id    uint64    // 容器实例编号（用于作用域订阅）
 	key   string    // object 当前字段名
+	keys  int       // object 已出现的 key 数量
 	index int       // array 当前索引
+	val   any       // 构建中的完整值（map[string]any 或 []any，需启用 Materialize）
+	need  []string  // object 尚未出现的必需字段（见 Parser.Require）
 }
 
 // parserState 表示 parser 的状态
@@ -269,3 +269,45 @@ func TestCompilePattern(t *testing.T) {
 		})
 	}
 }
+
+// TestSubscription_StringMode 测试增量模式与累积模式
+func TestSubscription_StringMode(t *testing.T) {
+	var deltas, accumulated, cumulative []string
+	p := NewParser()
+	p.On("$.text", func(ev Event) {
+		if ev.Value != nil && ev.Value.Append {
+			deltas = append(deltas, ev.Value.String())
+			accumulated = append(accumulated, ev.Value.Accumulated())
+		}
+	})
+	p.On("$.text", func(ev Event) {
+		if ev.Value != nil && !ev.Value.Complete {
+			if ev.Value.Append {
+				t.Error("cumulative subscription should not receive Append events")
+			}
+			cumulative = append(cumulative, ev.Value.String())
+		}
+	}, WithStringMode(StringCumulative))
+
+	for _, frag := range []string{`{"text": "Hel`, `lo, `, `wor`, `ld"}`} {
+		if err := p.FeedString(frag); err != nil {
+			t.Fatalf("FeedString() failed: %v", err)
+		}
+	}
+
+	wantDeltas := []string{"Hel", "lo, ", "wor", "ld"}
+	wantFull := []string{"Hel", "Hello, ", "Hello, wor", "Hello, world"}
+	check := func(name string, got, want []string) {
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %q, got %q", name, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s[%d] = %q, want %q", name, i, got[i], want[i])
+			}
+		}
+	}
+	check("deltas", deltas, wantDeltas)
+	check("accumulated", accumulated, wantFull)
+	check("cumulative", cumulative, wantFull)
+}
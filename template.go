@@ -0,0 +1,94 @@
+package stream
+
+import (
+	"slices"
+	"time"
+)
+
+// ParserTemplate 保存选项和已编译的订阅，用于快速创建相同配置的 Parser
+// 由模板创建的 Parser 共享 Handler，在多个 goroutine 中使用时 Handler 需自行保证并发安全
+type ParserTemplate struct {
+	opts       Options
+	subs       []*Subscription
+	middleware []Middleware
+	required   []requirement
+}
+
+// NewParserTemplate 使用选项创建模板，选项非法时返回错误
+func NewParserTemplate(opts ...Option) (*ParserTemplate, error) {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &ParserTemplate{
+		opts: o,
+		subs: make([]*Subscription, 0, 8),
+	}, nil
+}
+
+// On 向模板添加订阅，之后创建的 Parser 都会包含该订阅
+func (t *ParserTemplate) On(expr string, h Handler, opts ...SubscribeOption) *ParserTemplate {
+	t.subs = addSubscription(t.subs, newSubscription(expr, t.opts.PathSyntax, h, opts))
+	return t
+}
+
+// OnE 向模板添加可返回错误的订阅（见 HandlerE）
+func (t *ParserTemplate) OnE(expr string, h HandlerE, opts ...SubscribeOption) *ParserTemplate {
+	sub := newSubscription(expr, t.opts.PathSyntax, nil, opts)
+	sub.handlerE = h
+	t.subs = addSubscription(t.subs, sub)
+	return t
+}
+
+// Use 向模板添加作用于所有订阅的中间件（见 Parser.Use）
+func (t *ParserTemplate) Use(mw ...Middleware) *ParserTemplate {
+	t.middleware = append(t.middleware, mw...)
+	return t
+}
+
+// NewParser 创建一个使用模板选项和订阅的新 Parser，不会重新编译路径模式
+func (t *ParserTemplate) NewParser() *Parser {
+	subs := make([]*Subscription, len(t.subs), len(t.subs)+4)
+	copy(subs, t.subs)
+	p := newParser(t.opts, subs)
+	p.middleware = append([]Middleware(nil), t.middleware...)
+	p.required = slices.Clip(t.required)
+	return p
+}
+
+// Reset 清空解析状态（栈、错误、tokenizer 状态、缓冲区、文档计数等），保留选项和订阅，
+// 使 Parser 可以通过 sync.Pool 复用；尚未关闭的事件通道在发出已缓冲的事件后关闭，
+// 作用域订阅被移除，未完成的 Future 以 ErrFieldMissing 失败
+func (p *Parser) Reset() {
+	clear(p.stack)
+	p.stack = p.stack[:0]
+	p.state = pIdle
+	p.curValueKind = valNone
+	p.lastValueKind = ValueString
+	p.curString.Reset()
+	p.curNumber.Reset()
+	p.chunkBuffer.Reset()
+	p.numberFlushed = 0
+	p.keyFlushed = 0
+	p.chunkRunes = 0
+	p.lastFlush = time.Time{}
+	p.err = nil
+	p.cachedSegments = p.cachedSegments[:0]
+	p.segmentsDirty = true
+	p.bytes = 0
+	p.skipping = false
+	p.skipDepth = 0
+	p.docIndex = 0
+	p.inDocument = false
+	p.closed = false
+	p.rootValue = nil
+	p.tokenizer.Reset()
+	p.endSinks()
+	p.dropScopes(0)
+	clear(p.unions)
+	p.rejectFutures(nil)
+	p.reopenFutures()
+}
@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParser_Reset(t *testing.T) {
+	var values []string
+	p := NewParser()
+	p.On("$.status", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			values = append(values, ev.Value.String())
+		}
+	})
+
+	// 半途出错并留下未完成的字符串
+	if err := p.FeedString(`{"status": "ok"}}`); err == nil {
+		t.Fatal("expected error for extra brace")
+	}
+	p.Reset()
+	if p.Err() != nil {
+		t.Fatalf("expected no error after Reset, got %v", p.Err())
+	}
+	if err := p.FeedString(`{"status": "unfini`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	p.Reset()
+
+	var docs []int
+	p.On("$", func(ev Event) {
+		if ev.Type == EventDocumentEnd {
+			docs = append(docs, ev.Document)
+		}
+	})
+	if err := p.FeedString(`{"status": "again"}`); err != nil {
+		t.Fatalf("FeedString() after Reset failed: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	p.Reset()
+	if err := p.FeedString(`{"status": "reopened"}`); err != nil {
+		t.Fatalf("FeedString() after Close and Reset failed: %v", err)
+	}
+
+	want := []string{"ok", "again", "reopened"}
+	if len(values) != len(want) {
+		t.Fatalf("expected %v, got %v", want, values)
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("value[%d] = %q, want %q", i, values[i], want[i])
+		}
+	}
+	if len(docs) != 2 || docs[0] != 0 || docs[1] != 0 {
+		t.Errorf("expected document index to restart at 0, got %v", docs)
+	}
+}
+
+func TestParserTemplate(t *testing.T) {
+	tmpl, err := NewParserTemplate(WithCoercion(CoercionLLM))
+	if err != nil {
+		t.Fatalf("NewParserTemplate() failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	sum := 0
+	tmpl.On("$.n", func(ev Event) {
+		if ev.Value != nil && ev.Value.Complete {
+			mu.Lock()
+			sum += ev.Value.Int()
+			mu.Unlock()
+		}
+	})
+
+	pool := sync.Pool{New: func() any { return tmpl.NewParser() }}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := pool.Get().(*Parser)
+			defer func() {
+				p.Reset()
+				pool.Put(p)
+			}()
+			if err := p.FeedString(`{"n": "5"}`); err != nil {
+				t.Errorf("FeedString() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sum != 40 {
+		t.Errorf("expected sum 40, got %d", sum)
+	}
+
+	// 在某个 Parser 上追加订阅不影响模板
+	p := tmpl.NewParser()
+	p.On("$.extra", func(Event) {})
+	if len(tmpl.NewParser().subs) != 1 {
+		t.Error("subscriptions added to a parser must not leak into the template")
+	}
+	if p.Options().Coercion != CoercionLLM {
+		t.Errorf("expected template options, got %v", p.Options().Coercion)
+	}
+}
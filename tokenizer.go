@@ -3,6 +3,7 @@ package stream
 import (
 	"fmt"
 	"unicode"
+	"unicode/utf8"
 )
 
 // TokenType 表示 token 类型
@@ -81,9 +82,13 @@ func (ts tokenizerState) String() string {
 
 // Tokenizer 将字符流转换为 token 流
 type Tokenizer struct {
-	state tokenizerState // 当前状态
-	buf   []rune         // 临时缓冲区（用于 keyword）
-	emit  func(Token)    // token 输出回调
+	state     tokenizerState // 当前状态
+	buf       []rune         // 临时缓冲区（用于 keyword）
+	emit      func(Token)    // token 输出回调
+	n         int            // 当前字符串/数字已消费的字节数
+	maxString int            // 单个字符串的最大字节数，0 表示不限制
+	maxNumber int            // 单个数字的最大字节数，0 表示不限制
+	err       error          // 超出限制时的错误
 }
 
 // NewTokenizer 创建一个新的 Tokenizer
@@ -94,8 +99,40 @@ func NewTokenizer(emit func(Token)) *Tokenizer {
 	}
 }
 
+// SetLimits 设置字符串和数字的长度限制（只使用 MaxStringLength 和 MaxNumberLength）
+func (t *Tokenizer) SetLimits(l Limits) {
+	t.maxString = l.MaxStringLength
+	t.maxNumber = l.MaxNumberLength
+}
+
+// Err 返回超出限制时的错误，出错后 Tokenizer 不再产生 token
+func (t *Tokenizer) Err() error {
+	return t.err
+}
+
+// grow 累计当前字符串/数字的长度，超出限制时记录错误
+func (t *Tokenizer) grow(r rune, max int, limitErr error) bool {
+	t.n += utf8.RuneLen(r)
+	if max > 0 && t.n > max {
+		t.err = fmt.Errorf("%w: limit %d", limitErr, max)
+		return false
+	}
+	return true
+}
+
+// Reset 清空状态和错误，保留输出回调与长度限制
+func (t *Tokenizer) Reset() {
+	t.state = tIdle
+	t.buf = t.buf[:0]
+	t.n = 0
+	t.err = nil
+}
+
 // Consume 消费一个 rune，可能产生 0 个或多个 token
 func (t *Tokenizer) Consume(r rune) {
+	if t.err != nil {
+		return
+	}
 	switch t.state {
 	case tIdle:
 		t.consumeIdle(r)
@@ -127,9 +164,14 @@ func (t *Tokenizer) consumeIdle(r rune) {
 	case '"':
 		t.state = tString
 		t.buf = t.buf[:0]
+		t.n = 0
 	case ' ', '\n', '\r', '\t':
 	default:
 		if t.isDigit(r) || r == '-' {
+			t.n = 0
+			if !t.grow(r, t.maxNumber, ErrNumberTooLong) {
+				return
+			}
 			t.state = tNumber
 			t.buf = append(t.buf[:0], r)
 			t.emit(Token{Type: TokenNumberChunk, Value: string(r)})
@@ -151,6 +193,9 @@ func (t *Tokenizer) consumeString(r rune) {
 		t.emit(Token{Type: TokenStringEnd})
 		t.state = tIdle
 	default:
+		if !t.grow(r, t.maxString, ErrStringTooLong) {
+			return
+		}
 		t.emit(Token{
 			Type:  TokenStringChunk,
 			Value: string(r),
@@ -159,6 +204,9 @@ func (t *Tokenizer) consumeString(r rune) {
 }
 
 func (t *Tokenizer) consumeStringEscape(r rune) {
+	if !t.grow(r, t.maxString, ErrStringTooLong) {
+		return
+	}
 	t.emit(Token{
 		Type:  TokenStringChunk,
 		Value: string(r),
@@ -168,6 +216,9 @@ func (t *Tokenizer) consumeStringEscape(r rune) {
 
 func (t *Tokenizer) consumeNumber(r rune) {
 	if t.isNumberChar(r) {
+		if !t.grow(r, t.maxNumber, ErrNumberTooLong) {
+			return
+		}
 		t.emit(Token{
 			Type:  TokenNumberChunk,
 			Value: string(r),
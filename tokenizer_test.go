@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -413,3 +414,23 @@ func TestTokenizer_ComplexJSON(t *testing.T) {
 		t.Errorf("expected RBrace, got %v", tokens[len(tokens)-1].Type)
 	}
 }
+
+func TestTokenizer_Limits(t *testing.T) {
+	var tokens []Token
+	tok := NewTokenizer(func(t Token) {
+		tokens = append(tokens, t)
+	})
+	tok.SetLimits(Limits{MaxStringLength: 3})
+
+	for _, r := range `"abcd"` {
+		tok.Consume(r)
+	}
+
+	if !errors.Is(tok.Err(), ErrStringTooLong) {
+		t.Fatalf("expected ErrStringTooLong, got %v", tok.Err())
+	}
+	// 超出限制后不再产生 token
+	if len(tokens) != 3 {
+		t.Errorf("expected 3 tokens, got %d: %v", len(tokens), tokens)
+	}
+}
@@ -0,0 +1,162 @@
+package stream
+
+import (
+	"fmt"
+	"slices"
+)
+
+// unionRouter 按判别字段将数组元素（或其他对象）的事件路由到不同的 Handler
+// 路由器本身只保存配置，随订阅在 Fork 出的分支和模板创建的 Parser 之间共享
+type unionRouter struct {
+	depth    int                // 元素路径的段数
+	field    string             // 判别字段名
+	handlers map[string]Handler // 判别值 -> Handler
+}
+
+// unionState 路由器在单个 Parser 上的状态
+type unionState struct {
+	active bool    // 是否处于某个元素内部（或刚结束、等待 ArrayItem）
+	chosen Handler // 已确定的 Handler，nil 表示判别字段尚未到达
+	key    string  // 选中 Handler 时的判别值（用于检查点）
+	buffer []Event // 判别字段到达前缓冲的事件
+}
+
+// unionStates 保存 Parser 上各路由器的状态
+type unionStates map[*unionRouter]*unionState
+
+// clone 复制所有状态，用于 Fork 出共享订阅的分支 p；缓冲事件捕获的作用域改为指向 p
+func (us unionStates) clone(p *Parser) unionStates {
+	if len(us) == 0 {
+		return nil
+	}
+	c := make(unionStates, len(us))
+	for u, s := range us {
+		cs := *s
+		cs.buffer = slices.Clone(s.buffer)
+		for i, ev := range cs.buffer {
+			if ev.scope != nil {
+				sc := *ev.scope
+				sc.p = p
+				cs.buffer[i].scope = &sc
+			}
+		}
+		c[u] = &cs
+	}
+	return c
+}
+
+// unionState 返回路由器在该 Parser 上的状态，不存在时创建
+func (p *Parser) unionState(u *unionRouter) *unionState {
+	s := p.unions[u]
+	if s == nil {
+		if p.unions == nil {
+			p.unions = make(unionStates)
+		}
+		s = &unionState{}
+		p.unions[u] = s
+	}
+	return s
+}
+
+// OnUnion 订阅 expr 匹配的对象（如 $.blocks[*]），按其 field 字段的值选择 handlers 中的 Handler
+// 判别字段到达之前，该对象的事件（包括 ObjectStart）会被缓冲，到达后按顺序重放给选中的 Handler，
+// 之后的事件直接投递；判别值没有对应的 Handler 或对象结束时仍未出现判别字段，则丢弃该对象的事件
+// 非字符串的判别值按其 JSON 文本（如 "1"、"true"）查找 Handler
+func (p *Parser) OnUnion(expr string, field string, handlers map[string]Handler, opts ...SubscribeOption) *Parser {
+	pat, err := compilePatternSyntax(expr, p.opts.PathSyntax)
+	if err != nil {
+		panic(err)
+	}
+	u := &unionRouter{
+		depth:    len(pat.Segments),
+		field:    field,
+		handlers: handlers,
+	}
+	sub := newPatternSubscription(expr, pat, u.handle, opts)
+	sub.subtree = true
+	sub.containerPath = true
+	sub.union = u
+	p.subscribe(sub)
+	return p
+}
+
+// handle 接收元素及其子树的事件
+func (u *unionRouter) handle(ev Event) {
+	s := ev.parser.unionState(u)
+	if len(ev.pathSegments) == u.depth {
+		switch ev.Type {
+		case EventObjectStart:
+			s.active = true
+			s.chosen = nil
+			s.buffer = s.buffer[:0]
+		case EventFieldValue, EventArrayStart, EventArrayEnd:
+			// 元素本身不是对象
+			return
+		}
+	}
+	if !s.active {
+		return
+	}
+
+	if s.chosen != nil {
+		s.chosen(ev)
+	} else {
+		// 缓冲的事件稍后重放，此时已不在原来的投递过程中；
+		// 作用域在缓冲时捕获，重放时元素本身仍未结束，其作用域可以继续注册订阅
+		ev.scope = ev.Scope()
+		ev.stop = nil
+		ev.parser = nil
+		s.buffer = append(s.buffer, ev)
+		if u.isDiscriminator(ev) {
+			u.choose(s, discriminatorKey(ev.Value))
+		}
+	}
+
+	if len(ev.pathSegments) == u.depth && (ev.Type == EventObjectEnd || ev.Type == EventArrayItem) {
+		if ev.Type == EventArrayItem || s.chosen == nil {
+			s.active = false
+		}
+		if s.chosen == nil {
+			clear(s.buffer)
+			s.buffer = s.buffer[:0]
+		}
+	}
+}
+
+// isDiscriminator 判断事件是否为元素判别字段的完整值
+func (u *unionRouter) isDiscriminator(ev Event) bool {
+	if ev.Type != EventFieldValue || ev.Value == nil || !ev.Value.Complete || len(ev.pathSegments) != u.depth+1 {
+		return false
+	}
+	last := ev.pathSegments[u.depth]
+	return last.Kind == SegField && last.Value == u.field
+}
+
+// discriminatorKey 返回判别值用于查找 Handler 的文本：字符串为其内容，其他类型为 JSON 文本（如 1、true、null）
+// 直接读取原始值，不受强制转换策略影响
+func discriminatorKey(v *PartialValue) string {
+	if v.Kind == ValueNull {
+		return "null"
+	}
+	return fmt.Sprint(v.Value)
+}
+
+// choose 选择 Handler 并重放缓冲的事件
+func (u *unionRouter) choose(s *unionState, value string) {
+	h := u.handler(value)
+	s.chosen = h
+	s.key = value
+	for i, ev := range s.buffer {
+		h(ev)
+		s.buffer[i] = Event{}
+	}
+	s.buffer = s.buffer[:0]
+}
+
+// handler 返回判别值对应的 Handler，没有对应的 Handler 时返回丢弃事件的空函数
+func (u *unionRouter) handler(value string) Handler {
+	if h, ok := u.handlers[value]; ok {
+		return h
+	}
+	return func(Event) {}
+}
@@ -0,0 +1,184 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_OnUnion(t *testing.T) {
+	var log []string
+	route := func(name string) Handler {
+		return func(ev Event) {
+			s := name + " " + ev.Type.String() + " " + ev.Path()
+			if ev.Type == EventFieldValue && ev.Value.Complete {
+				s += "=" + ev.Value.String()
+			}
+			log = append(log, s)
+		}
+	}
+
+	p := NewParser()
+	p.OnUnion("$.blocks[*]", "type", map[string]Handler{
+		"text": route("text"),
+		"call": route("call"),
+	})
+
+	input := `{"blocks": [` +
+		`{"text": "hi", "type": "text"},` +
+		`{"type": "call", "args": {"q": 1}},` +
+		`{"type": "image", "url": "x"},` +
+		`{"content": "no type"},` +
+		`"scalar"` +
+		`]}`
+	if err := p.FeedString(input); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	want := []string{
+		"text ObjectStart $.blocks[0]",
+		"text FieldValue $.blocks[0].text=hi",
+		"text FieldValue $.blocks[0].type=text",
+		"text ObjectEnd $.blocks[0]",
+		"text ArrayItem $.blocks[0]",
+		"call ObjectStart $.blocks[1]",
+		"call FieldValue $.blocks[1].type=call",
+		"call ObjectStart $.blocks[1].args",
+		"call FieldValue $.blocks[1].args.q=1",
+		"call ObjectEnd $.blocks[1].args",
+		"call ObjectEnd $.blocks[1]",
+		"call ArrayItem $.blocks[1]",
+	}
+	if strings.Join(log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected routing:\n%s", strings.Join(log, "\n"))
+	}
+}
+
+func TestParser_OnUnionStreaming(t *testing.T) {
+	var chunks []string
+	p := NewParser()
+	p.OnUnion("$[*]", "type", map[string]Handler{
+		"text": func(ev Event) {
+			if ev.Type == EventFieldValue && ev.Value.Append && ev.Path() != "$[0].type" {
+				chunks = append(chunks, ev.Value.String())
+			}
+		},
+	})
+
+	p.FeedString(`[{"text": "a`)
+	p.FeedString(`b", "type": "te`)
+	if len(chunks) != 0 {
+		t.Fatalf("expected events to be buffered before the discriminator, got %v", chunks)
+	}
+	p.FeedString(`xt", "more": "c`)
+	if strings.Join(chunks, ",") != "a,b,c" {
+		t.Fatalf("expected buffered chunks to be replayed before new ones, got %v", chunks)
+	}
+	p.FeedString(`d"}]`)
+	if strings.Join(chunks, ",") != "a,b,c,d" {
+		t.Errorf("expected later chunks to stream directly, got %v", chunks)
+	}
+}
+
+func TestParser_OnUnionFork(t *testing.T) {
+	var log []string
+	route := func(name string) Handler {
+		return func(ev Event) {
+			s := name + " " + ev.Type.String() + " " + ev.Path()
+			if ev.Type == EventFieldValue && ev.Value.Complete {
+				s += "=" + ev.Value.String()
+			}
+			log = append(log, s)
+		}
+	}
+
+	p := NewParser()
+	p.OnUnion("$.blocks[*]", "type", map[string]Handler{
+		"text": route("text"),
+		"img":  route("img"),
+	})
+	if err := p.FeedString(`{"blocks":[{"body":"a",`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+
+	f := p.Fork(ForkShareSubscriptions)
+	f.FeedString(`"type":"img"}]}`)
+	p.FeedString(`"type":"text"}]}`)
+
+	want := []string{
+		"img ObjectStart $.blocks[0]",
+		"img FieldValue $.blocks[0].body=a",
+		"img FieldValue $.blocks[0].type=img",
+		"img ObjectEnd $.blocks[0]",
+		"img ArrayItem $.blocks[0]",
+		"text ObjectStart $.blocks[0]",
+		"text FieldValue $.blocks[0].body=a",
+		"text FieldValue $.blocks[0].type=text",
+		"text ObjectEnd $.blocks[0]",
+		"text ArrayItem $.blocks[0]",
+	}
+	if strings.Join(log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected routing:\n%s", strings.Join(log, "\n"))
+	}
+}
+
+// TestParser_OnUnionNonString 测试非字符串判别值按 JSON 文本匹配，且不受强制转换策略影响
+func TestParser_OnUnionNonString(t *testing.T) {
+	var got []string
+	route := func(name string) Handler {
+		return func(ev Event) {
+			if ev.Type == EventObjectEnd && len(ev.pathSegments) == 1 {
+				got = append(got, name)
+			}
+		}
+	}
+
+	p := NewParser()
+	p.SetCoercion(CoercionStrict)
+	p.OnUnion("$[*]", "type", map[string]Handler{
+		"1":    route("one"),
+		"true": route("yes"),
+		"null": route("none"),
+	})
+	if err := p.FeedString(`[{"type": 1}, {"type": true}, {"type": null}, {"type": "1"}]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if want := "one yes none one"; strings.Join(got, " ") != want {
+		t.Errorf("expected %q, got %q", want, strings.Join(got, " "))
+	}
+}
+
+// TestParser_OnUnionScope 测试重放的 ObjectStart 仍可通过 Scope 在元素上注册订阅（包括 Fork 出的分支）
+func TestParser_OnUnionScope(t *testing.T) {
+	var log []string
+	list := func(ev Event) {
+		if ev.Type != EventObjectStart || len(ev.pathSegments) != 1 {
+			return
+		}
+		ev.Scope().On(".items[*]", func(ev Event) {
+			log = append(log, ev.Path()+"="+ev.Value.String())
+		}, WithEventTypes(EventFieldValue))
+	}
+
+	p := NewParser()
+	p.OnUnion("$[*]", "type", map[string]Handler{"list": list})
+	if err := p.FeedString(`[{"title": "a", "type": "list", "items": [1, 2]}, {"items": [3]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	// 分支复制了尚未重放的缓冲事件，其作用域应指向分支自身
+	f := p.Fork(ForkShareSubscriptions)
+	if err := p.FeedString(`, "type": "list", "items": [4]}, {"items": [5]}]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	want := []string{"$[0].items[0]=1", "$[0].items[1]=2", "$[1].items[0]=4"}
+	if strings.Join(log, " ") != strings.Join(want, " ") {
+		t.Errorf("expected %q, got %q", want, log)
+	}
+
+	log = nil
+	if err := f.FeedString(`, "type": "list", "items": [6]}]`); err != nil {
+		t.Fatalf("FeedString() failed: %v", err)
+	}
+	if want := []string{"$[1].items[0]=6"}; strings.Join(log, " ") != strings.Join(want, " ") {
+		t.Errorf("fork: expected %q, got %q", want, log)
+	}
+}
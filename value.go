@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 )
@@ -45,13 +46,29 @@ func (vk ValueKind) String() string {
 
 // PartialValue 表示一个部分值，支持流式追加和完成标记
 type PartialValue struct {
-	Kind     ValueKind // 值类型
-	Value    any       // 值内容
-	Append   bool      // 是否为追加模式
-	Complete bool      // 是否完成
+	Kind         ValueKind      // 值类型
+	Value        any            // 值内容
+	Append       bool           // 是否为追加模式
+	Complete     bool           // 是否完成
+	policy       CoercionPolicy // 访问器使用的强制转换策略
+	pathSegments []PathSegment  // 值所在路径（用于错误信息）
+	pathSyntax   PathSyntax     // 路径语法
+	accumulated  string         // Append 事件截至目前的完整内容（零拷贝引用）
 }
 
-// String 转换为字符串
+// Accumulated 返回截至当前事件的完整内容
+// 对 Append 事件是所有增量的拼接结果，对完整值等同于 String()
+func (pv *PartialValue) Accumulated() string {
+	if pv == nil {
+		return ""
+	}
+	if pv.Append {
+		return pv.accumulated
+	}
+	return pv.String()
+}
+
+// String 转换为字符串，只负责格式化，不受强制转换策略影响（需要类型检查时使用 StringE）
 func (pv *PartialValue) String() string {
 	if pv == nil || pv.Value == nil {
 		return ""
@@ -88,6 +105,8 @@ func (pv *PartialValue) convertToInt64(v any) (int64, bool) {
 		return int64(val), true
 	case float64:
 		return int64(val), true
+	case json.Number:
+		return pv.convertToInt64(string(val))
 	case string:
 		if i, err := strconv.ParseInt(val, 10, 64); err == nil {
 			return i, true
@@ -125,6 +144,8 @@ func (pv *PartialValue) convertToFloat64(v any) (float64, bool) {
 		return float64(val), true
 	case uint64:
 		return float64(val), true
+	case json.Number:
+		return pv.convertToFloat64(string(val))
 	case string:
 		if f, err := strconv.ParseFloat(val, 64); err == nil {
 			return f, true
@@ -138,7 +159,11 @@ func (pv *PartialValue) Int() int {
 	if pv == nil || pv.Value == nil {
 		return 0
 	}
-	if i64, ok := pv.convertToInt64(pv.Value); ok {
+	v, ok := pv.numericValue()
+	if !ok {
+		return 0
+	}
+	if i64, ok := pv.convertToInt64(v); ok {
 		return int(i64)
 	}
 	return 0
@@ -149,7 +174,11 @@ func (pv *PartialValue) Int64() int64 {
 	if pv == nil || pv.Value == nil {
 		return 0
 	}
-	if i64, ok := pv.convertToInt64(pv.Value); ok {
+	v, ok := pv.numericValue()
+	if !ok {
+		return 0
+	}
+	if i64, ok := pv.convertToInt64(v); ok {
 		return i64
 	}
 	return 0
@@ -160,7 +189,11 @@ func (pv *PartialValue) Float64() float64 {
 	if pv == nil || pv.Value == nil {
 		return 0.0
 	}
-	if f64, ok := pv.convertToFloat64(pv.Value); ok {
+	v, ok := pv.numericValue()
+	if !ok {
+		return 0.0
+	}
+	if f64, ok := pv.convertToFloat64(v); ok {
 		return f64
 	}
 	return 0.0
@@ -168,34 +201,37 @@ func (pv *PartialValue) Float64() float64 {
 
 // Bool 转换为 bool
 func (pv *PartialValue) Bool() bool {
-	if pv == nil || pv.Value == nil {
-		return false
-	}
+	b, _ := pv.toBool()
+	return b
+}
+
+// lenientBool 宽松模式下的 bool 转换，字符串和数字按真值解释
+func (pv *PartialValue) lenientBool() (bool, bool) {
 	switch v := pv.Value.(type) {
 	case bool:
-		return v
+		return v, true
 	case string:
 		if b, err := strconv.ParseBool(v); err == nil {
-			return b
+			return b, true
 		}
 		switch v {
 		case "true", "True", "TRUE":
-			return true
+			return true, true
 		case "false", "False", "FALSE":
-			return false
+			return false, true
 		}
 		if i, err := strconv.Atoi(v); err == nil {
-			return i != 0
+			return i != 0, true
 		}
 		if f, err := strconv.ParseFloat(v, 64); err == nil {
-			return f != 0.0
+			return f != 0.0, true
 		}
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		return pv.Int() != 0
-	case float32, float64:
-		return pv.Float64() != 0.0
+		return pv.Int() != 0, true
+	case float32, float64, json.Number:
+		return pv.Float64() != 0.0, true
 	}
-	return false
+	return false, false
 }
 
 // IsNull 判断是否为 null